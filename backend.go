@@ -1,7 +1,7 @@
 package main
 
 import (
-	"fmt"
+	"slices"
 	"time"
 )
 
@@ -15,6 +15,110 @@ type Backend interface {
 	DeleteCard(cardID string) error
 }
 
+// IssueConverter is implemented by backends that can turn draft cards into issues
+type IssueConverter interface {
+	ConvertToIssue(card *Card) error
+}
+
+// MoveQueuer is implemented by backends that batch rapid moves of the same card
+// QueueMove returns immediately; the returned function sends the move (or skips it
+// if a later move replaced it) and should run in the background
+type MoveQueuer interface {
+	QueueMove(cardID, toColumn string) func() error
+}
+
+// BoardCacher is implemented by remote backends that keep a local copy of the last fetched board
+type BoardCacher interface {
+	// CachedBoard returns the cached board and when it was fetched
+	CachedBoard() (*Board, time.Time, error)
+}
+
+// OutboxReplayer is implemented by remote backends that queue mutations while offline
+type OutboxReplayer interface {
+	PendingCards() map[string]bool // IDs of cards with unsent changes
+	ReplayOutbox() OutboxReplayResult
+}
+
+// CardQuerier is implemented by backends that can select cards without loading the whole board
+type CardQuerier interface {
+	QueryCards(query CardQuery) ([]*Card, error)
+}
+
+// CardQuery selects cards for listings and reports; empty fields match every card
+type CardQuery struct {
+	Column         string   // Only cards in this column
+	ExcludeColumns []string // Skip cards in these columns
+	Tag            string   // Only cards with this tag
+	HasDueDate     bool     // Only cards with a due date
+	DueBy          string   // Only cards due on or before this date (YYYY-MM-DD)
+}
+
+// Matches reports whether the query selects a card
+func (q CardQuery) Matches(card *Card) bool {
+	if q.Column != "" && card.Column != q.Column {
+		return false
+	}
+	if slices.Contains(q.ExcludeColumns, card.Column) {
+		return false
+	}
+	if q.Tag != "" && !slices.Contains(card.Tags, q.Tag) {
+		return false
+	}
+	if (q.HasDueDate || q.DueBy != "") && card.DueDate == "" {
+		return false
+	}
+	return q.DueBy == "" || q.dueBy(card)
+}
+
+// dueBy compares the card's due date with DueBy as calendar dates, since hand-edited
+// boards may use any layout ParseDueDate accepts
+func (q CardQuery) dueBy(card *Card) bool {
+	limit, err := ParseDueDate(q.DueBy)
+	if err != nil {
+		return card.DueDate <= q.DueBy
+	}
+	due, ok := card.Due()
+	return ok && !due.After(limit)
+}
+
+// QueryCards returns the cards a query selects, in board order
+// board is the backend's already loaded board, if any; backends that implement
+// CardQuerier run the query themselves
+func QueryCards(backend Backend, board *Board, query CardQuery) ([]*Card, error) {
+	if querier, ok := backend.(CardQuerier); ok {
+		return querier.QueryCards(query)
+	}
+	if board == nil {
+		var err error
+		if board, err = backend.LoadBoard(); err != nil {
+			return nil, err
+		}
+	}
+
+	cards := []*Card{}
+	for _, card := range board.Cards {
+		if query.Matches(card) {
+			cards = append(cards, card)
+		}
+	}
+	return cards, nil
+}
+
+// NewFileBackend creates the backend for a local board, chosen by its path: a board
+// directory (.tkan), SQLite for .db, a Markdown task list for .md, YAML otherwise
+func NewFileBackend(filePath string) Backend {
+	if isDirectoryBoard(filePath) {
+		return NewDirectoryBackend(filePath)
+	}
+	if isSQLiteBoard(filePath) {
+		return NewSQLiteBackend(filePath)
+	}
+	if isMarkdownBoard(filePath) {
+		return NewMarkdownBackend(filePath)
+	}
+	return NewLocalBackend(filePath)
+}
+
 // LocalBackend implements Backend using local YAML files
 type LocalBackend struct {
 	filePath string
@@ -78,18 +182,8 @@ func (l *LocalBackend) CreateCard(title, description, column string) (*Card, err
 		return nil, err
 	}
 
-	// Generate new ID
-	maxID := 0
-	for _, card := range board.Cards {
-		var id int
-		fmt.Sscanf(card.ID, "%d", &id)
-		if id > maxID {
-			maxID = id
-		}
-	}
-
 	newCard := &Card{
-		ID:          fmt.Sprintf("%d", maxID+1),
+		ID:          board.NextCardID(),
 		Title:       title,
 		Description: description,
 		Column:      column,
@@ -106,7 +200,20 @@ func (l *LocalBackend) CreateCard(title, description, column string) (*Card, err
 	return newCard, nil
 }
 
-// DeleteCard removes a card (moves to archive)
+// DeleteCard removes a card from the board
+// Use MoveCard(cardID, "ARCHIVE") to archive a card instead
 func (l *LocalBackend) DeleteCard(cardID string) error {
-	return l.MoveCard(cardID, "ARCHIVE")
+	board, err := l.LoadBoard()
+	if err != nil {
+		return err
+	}
+
+	for i, card := range board.Cards {
+		if card.ID == cardID {
+			board.Cards = append(board.Cards[:i], board.Cards[i+1:]...)
+			break
+		}
+	}
+
+	return l.SaveBoard(board)
 }
\ No newline at end of file
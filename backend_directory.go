@@ -0,0 +1,514 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DirectoryBackend implements Backend for a board stored as a directory (.tkan/):
+//
+//	.tkan/board.yaml     name, description, columns, templates, recurring rules
+//	.tkan/cards/<id>.md  one card per file: YAML front matter, then the description
+//
+// Moving or editing a card rewrites only that card's file, and files whose
+// content didn't change are never rewritten, so diffs and merges stay small.
+// Cards are listed in ID order, except in columns that were rearranged: the
+// files of those cards hold their position.
+type DirectoryBackend struct {
+	dir string
+
+	mu    sync.Mutex
+	known map[string][]byte // Card files as last read or written, by card ID
+}
+
+// NewDirectoryBackend creates a backend for the board directory dir
+func NewDirectoryBackend(dir string) *DirectoryBackend {
+	return &DirectoryBackend{dir: dir}
+}
+
+// isDirectoryBoard reports whether a board path is a board directory: a path ending
+// in .tkan, or a directory holding a board.yaml
+func isDirectoryBoard(path string) bool {
+	if strings.HasSuffix(filepath.Base(path), ".tkan") {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(path, "board.yaml"))
+	return err == nil
+}
+
+// dirBoardFile is the content of board.yaml: the board without its cards
+// The board's modification time isn't stored, so saving doesn't touch the file
+// unless the board itself changed
+type dirBoardFile struct {
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description,omitempty"`
+	URL         string           `yaml:"url,omitempty"`
+	Columns     []dirColumn      `yaml:"columns"`
+	Templates   []CardTemplate   `yaml:"templates,omitempty"`
+	Recurring   []RecurrenceRule `yaml:"recurring,omitempty"`
+	Sync        *SyncState       `yaml:"sync,omitempty"`
+	CreatedAt   time.Time        `yaml:"created_at"`
+}
+
+// dirColumn is a column in board.yaml
+type dirColumn struct {
+	Name string `yaml:"name"`
+}
+
+// dirCardFrontMatter is the front matter of a card file; the ID is the file name
+// and the description is the body. Position is the card's place in a rearranged
+// column, counting from 1; it's omitted for columns in ID order.
+type dirCardFrontMatter struct {
+	Title       string    `yaml:"title"`
+	Column      string    `yaml:"column"`
+	Position    int       `yaml:"position,omitempty"`
+	Tags        []string  `yaml:"tags,omitempty"`
+	Assignee    string    `yaml:"assignee,omitempty"`
+	DueDate     string    `yaml:"due_date,omitempty"`
+	URL         string    `yaml:"url,omitempty"`
+	ContentType string    `yaml:"content_type,omitempty"`
+	ContentID   string    `yaml:"content_id,omitempty"`
+	RemoteID    string    `yaml:"remote_id,omitempty"`
+	CreatedAt   time.Time `yaml:"created_at"`
+	ModifiedAt  time.Time `yaml:"modified_at"`
+}
+
+// boardPath returns the path of board.yaml
+func (d *DirectoryBackend) boardPath() string {
+	return filepath.Join(d.dir, "board.yaml")
+}
+
+// cardsDir returns the directory holding the card files
+func (d *DirectoryBackend) cardsDir() string {
+	return filepath.Join(d.dir, "cards")
+}
+
+// cardPath returns the file of a card, rejecting IDs that aren't usable as file names
+func (d *DirectoryBackend) cardPath(id string) (string, error) {
+	if id == "" || strings.HasPrefix(id, ".") || strings.ContainsAny(id, `/\`) {
+		return "", fmt.Errorf("card ID %q can't be used as a file name", id)
+	}
+	return filepath.Join(d.cardsDir(), id+".md"), nil
+}
+
+// LoadBoard reads board.yaml and every card file
+func (d *DirectoryBackend) LoadBoard() (*Board, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := os.ReadFile(d.boardPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read board file: %w", err)
+	}
+	var file dirBoardFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", d.boardPath(), err)
+	}
+
+	board := &Board{
+		Name:        file.Name,
+		Description: file.Description,
+		URL:         file.URL,
+		Cards:       []*Card{},
+		Templates:   file.Templates,
+		Recurring:   file.Recurring,
+		Sync:        file.Sync,
+		CreatedAt:   file.CreatedAt,
+		ModifiedAt:  file.CreatedAt,
+	}
+	for _, col := range file.Columns {
+		board.Columns = append(board.Columns, Column{Name: col.Name})
+	}
+
+	known := map[string][]byte{}
+	positions := map[string]int{}
+	entries, err := os.ReadDir(d.cardsDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read cards: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != ".md" {
+			continue
+		}
+		card, position, err := d.readCard(strings.TrimSuffix(name, ".md"))
+		if err != nil {
+			return nil, err
+		}
+		if known[card.ID], err = renderCardFile(card, position); err != nil {
+			return nil, err
+		}
+		positions[card.ID] = position
+		board.Cards = append(board.Cards, card)
+		if card.ModifiedAt.After(board.ModifiedAt) {
+			board.ModifiedAt = card.ModifiedAt
+		}
+	}
+
+	// Positioned cards come first, so cards moved into a rearranged column go last
+	slices.SortStableFunc(board.Cards, func(a, b *Card) int {
+		pa, pb := positions[a.ID], positions[b.ID]
+		switch {
+		case pa == pb:
+			return compareCardIDs(a.ID, b.ID)
+		case pa == 0:
+			return 1
+		case pb == 0:
+			return -1
+		}
+		return pa - pb
+	})
+	board.PopulateColumnCards()
+	d.known = known
+	return board, nil
+}
+
+// cardPositions returns the positions of the cards in columns that aren't in ID order
+func cardPositions(board *Board) map[string]int {
+	onBoard := map[string]bool{}
+	for _, card := range board.Cards {
+		onBoard[card.ID] = true
+	}
+	positions := map[string]int{}
+	for _, col := range board.Columns {
+		ids := columnCardIDs(board, col, onBoard)
+		if slices.IsSortedFunc(ids, compareCardIDs) {
+			continue
+		}
+		for i, id := range ids {
+			positions[id] = i + 1
+		}
+	}
+	return positions
+}
+
+// columnCardIDs returns the IDs of a column's cards in the order the column shows
+// them (the TUI rearranges Column.Cards), then any others in board.Cards order
+func columnCardIDs(board *Board, col Column, onBoard map[string]bool) []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, card := range col.Cards {
+		if card.Column == col.Name && onBoard[card.ID] && !seen[card.ID] {
+			ids = append(ids, card.ID)
+			seen[card.ID] = true
+		}
+	}
+	for _, card := range board.Cards {
+		if card.Column == col.Name && !seen[card.ID] {
+			ids = append(ids, card.ID)
+			seen[card.ID] = true
+		}
+	}
+	return ids
+}
+
+// compareCardIDs orders numeric IDs numerically, before other IDs in string order
+func compareCardIDs(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return na - nb
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// readCard reads and parses a card file, returning the card and its position
+func (d *DirectoryBackend) readCard(id string) (*Card, int, error) {
+	path, err := d.cardPath(id)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read card: %w", err)
+	}
+	card, position, err := parseCardFile(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	card.ID = id
+	return card, position, nil
+}
+
+// parseCardFile parses "---\n<front matter>---\n<description>"
+func parseCardFile(data []byte) (*Card, int, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return nil, 0, fmt.Errorf("missing front matter")
+	}
+	front, body, ok := strings.Cut(text[len("---\n"):], "\n---\n")
+	if !ok {
+		if front, ok = strings.CutSuffix(text[len("---\n"):], "\n---"); !ok {
+			return nil, 0, fmt.Errorf("front matter isn't closed with ---")
+		}
+	}
+
+	var fm dirCardFrontMatter
+	if err := yaml.Unmarshal([]byte(front), &fm); err != nil {
+		return nil, 0, err
+	}
+	return &Card{
+		Title:       fm.Title,
+		Description: strings.TrimSuffix(body, "\n"),
+		Tags:        fm.Tags,
+		Assignee:    fm.Assignee,
+		DueDate:     fm.DueDate,
+		URL:         fm.URL,
+		CreatedAt:   fm.CreatedAt,
+		ModifiedAt:  fm.ModifiedAt,
+		Column:      fm.Column,
+		ContentType: fm.ContentType,
+		ContentID:   fm.ContentID,
+		RemoteID:    fm.RemoteID,
+	}, fm.Position, nil
+}
+
+// renderCardFile renders a card as front matter followed by its description
+func renderCardFile(card *Card, position int) ([]byte, error) {
+	front, err := yaml.Marshal(dirCardFrontMatter{
+		Title:       card.Title,
+		Column:      card.Column,
+		Position:    position,
+		Tags:        card.Tags,
+		Assignee:    card.Assignee,
+		DueDate:     card.DueDate,
+		URL:         card.URL,
+		ContentType: card.ContentType,
+		ContentID:   card.ContentID,
+		RemoteID:    card.RemoteID,
+		CreatedAt:   card.CreatedAt,
+		ModifiedAt:  card.ModifiedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal card %s: %w", card.ID, err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(front)
+	buf.WriteString("---\n")
+	if card.Description != "" {
+		buf.WriteString(card.Description)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// writeIfChanged writes data to path unless the file already holds exactly data
+func writeIfChanged(path string, data []byte) error {
+	if old, err := os.ReadFile(path); err == nil && bytes.Equal(old, data) {
+		return nil
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// writeCard writes a card's file
+func (d *DirectoryBackend) writeCard(card *Card, position int) error {
+	data, err := renderCardFile(card, position)
+	if err != nil {
+		return err
+	}
+	return d.writeCardFile(card.ID, data)
+}
+
+// writeCardFile writes the rendered file of a card and remembers it
+func (d *DirectoryBackend) writeCardFile(id string, data []byte) error {
+	path, err := d.cardPath(id)
+	if err != nil {
+		return err
+	}
+	if err := writeIfChanged(path, data); err != nil {
+		return fmt.Errorf("failed to write card: %w", err)
+	}
+	if d.known == nil {
+		d.known = map[string][]byte{}
+	}
+	d.known[id] = data
+	return nil
+}
+
+// SaveBoard writes board.yaml and the card files, creating the directory if needed
+// Only cards that changed since they were loaded are written, and only the files of
+// loaded cards that are gone from the board are removed, so card files changed or
+// added meanwhile (by git pull or tkan add, say) are kept
+func (d *DirectoryBackend) SaveBoard(board *Board) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	board.ModifiedAt = time.Now()
+	if err := os.MkdirAll(d.cardsDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create board directory: %w", err)
+	}
+
+	file := dirBoardFile{
+		Name:        board.Name,
+		Description: board.Description,
+		URL:         board.URL,
+		Columns:     []dirColumn{},
+		Templates:   board.Templates,
+		Recurring:   board.Recurring,
+		Sync:        board.Sync,
+		CreatedAt:   board.CreatedAt,
+	}
+	for _, col := range board.Columns {
+		file.Columns = append(file.Columns, dirColumn{Name: col.Name})
+	}
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to marshal board to YAML: %w", err)
+	}
+	if err := writeIfChanged(d.boardPath(), data); err != nil {
+		return fmt.Errorf("failed to write board file: %w", err)
+	}
+
+	positions := cardPositions(board)
+	onBoard := map[string]bool{}
+	for _, card := range board.Cards {
+		onBoard[card.ID] = true
+		data, err := renderCardFile(card, positions[card.ID])
+		if err != nil {
+			return err
+		}
+		if known, ok := d.known[card.ID]; ok && bytes.Equal(known, data) {
+			continue
+		}
+		if err := d.writeCardFile(card.ID, data); err != nil {
+			return err
+		}
+	}
+
+	for id := range d.known {
+		if onBoard[id] {
+			continue
+		}
+		path, err := d.cardPath(id)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove deleted card: %w", err)
+		}
+		delete(d.known, id)
+	}
+	return nil
+}
+
+// MoveCard moves a card to a different column, rewriting only its file
+// The card loses its position, so it goes after the cards of a rearranged column
+func (d *DirectoryBackend) MoveCard(cardID string, toColumn string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	card, _, err := d.readCard(cardID)
+	if err != nil {
+		return err
+	}
+	card.Column = toColumn
+	card.ModifiedAt = time.Now()
+	return d.writeCard(card, 0)
+}
+
+// UpdateCard updates a card's details, keeping its position
+func (d *DirectoryBackend) UpdateCard(card *Card) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	path, err := d.cardPath(card.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("card not found: %s", card.ID)
+	}
+	_, position, err := d.readCard(card.ID)
+	if err != nil {
+		return err
+	}
+	return d.writeCard(card, position)
+}
+
+// CreateCard writes a new card file, numbered one past the largest numeric ID
+func (d *DirectoryBackend) CreateCard(title, description, column string) (*Card, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, err := os.Stat(d.boardPath()); err != nil {
+		return nil, fmt.Errorf("failed to read board file: %w", err)
+	}
+	entries, err := os.ReadDir(d.cardsDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read cards: %w", err)
+	}
+	maxID := 0
+	for _, entry := range entries {
+		if id, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ".md")); err == nil && id > maxID {
+			maxID = id
+		}
+	}
+
+	card := &Card{
+		ID:          strconv.Itoa(maxID + 1),
+		Title:       title,
+		Description: description,
+		Column:      column,
+		CreatedAt:   time.Now(),
+		ModifiedAt:  time.Now(),
+	}
+	data, err := renderCardFile(card, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(d.cardsDir(), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create board directory: %w", err)
+	}
+
+	// O_EXCL so a card created concurrently under the same ID isn't overwritten
+	path, _ := d.cardPath(card.ID)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write card: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write card: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write card: %w", err)
+	}
+	if d.known == nil {
+		d.known = map[string][]byte{}
+	}
+	d.known[card.ID] = data
+	return card, nil
+}
+
+// DeleteCard removes a card's file
+// Use MoveCard(cardID, "ARCHIVE") to archive a card instead
+func (d *DirectoryBackend) DeleteCard(cardID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	path, err := d.cardPath(cardID)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete card: %w", err)
+	}
+	delete(d.known, cardID)
+	return nil
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDirectoryBackendCardFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".tkan")
+	backend := NewDirectoryBackend(dir)
+	if err := backend.SaveBoard(sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "cards", "2.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `---
+title: Add OAuth
+column: PROGRESS
+url: https://example.com/2
+created_at: 2026-01-02T03:04:05Z
+modified_at: 2026-01-02T03:04:05Z
+---
+Token refresh
+`
+	if string(data) != want {
+		t.Errorf("cards/2.md =\n%s\nwant:\n%s", data, want)
+	}
+
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := sqliteTestBoard()
+	if board.Name != original.Name || len(board.Columns) != 3 || len(board.Recurring) != 1 {
+		t.Errorf("board = %+v", board)
+	}
+	for i, card := range board.Cards {
+		if card.ID != original.Cards[i].ID || !cardsEqual(card, original.Cards[i]) {
+			t.Errorf("card %d = %+v, want %+v", i, card, original.Cards[i])
+		}
+	}
+}
+
+func TestDirectoryBackendTouchesOnlyChangedFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".tkan")
+	backend := NewDirectoryBackend(dir)
+	if err := backend.SaveBoard(sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Backdate every file so a rewrite shows up as a newer modification time
+	old := time.Now().Add(-time.Hour)
+	files, _ := filepath.Glob(filepath.Join(dir, "*", "*.md"))
+	files = append(files, filepath.Join(dir, "board.yaml"))
+	for _, f := range files {
+		os.Chtimes(f, old, old)
+	}
+	touched := func() []string {
+		var names []string
+		for _, f := range files {
+			if info, err := os.Stat(f); err == nil && info.ModTime().After(old) {
+				names = append(names, filepath.Base(f))
+			}
+		}
+		return names
+	}
+
+	if err := backend.MoveCard("1", "DONE"); err != nil {
+		t.Fatal(err)
+	}
+	if got := touched(); !slices.Equal(got, []string{"1.md"}) {
+		t.Errorf("move rewrote %v", got)
+	}
+
+	// Saving the whole board after the move rewrites nothing else
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+	if got := touched(); !slices.Equal(got, []string{"1.md"}) {
+		t.Errorf("save rewrote %v", got)
+	}
+
+	card, err := backend.CreateCard("Write docs", "Usage and examples", "TODO")
+	if err != nil || card.ID != "4" {
+		t.Fatalf("created %+v, %v", card, err)
+	}
+	if err := backend.DeleteCard("2"); err != nil {
+		t.Fatal(err)
+	}
+	board, err = backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cards []string
+	for _, c := range board.Cards {
+		cards = append(cards, c.ID+":"+c.Column)
+	}
+	if !slices.Equal(cards, []string{"1:DONE", "3:DONE", "4:TODO"}) {
+		t.Errorf("cards = %v", cards)
+	}
+}
+
+// columnIDs returns the IDs of a column's cards, in order
+func columnIDs(board *Board, column string) []string {
+	var ids []string
+	for _, col := range board.Columns {
+		if col.Name == column {
+			for _, card := range col.Cards {
+				ids = append(ids, card.ID)
+			}
+		}
+	}
+	return ids
+}
+
+func TestDirectoryBackendKeepsColumnOrder(t *testing.T) {
+	root := t.TempDir()
+	yamlPath := filepath.Join(root, ".tkan.yaml")
+	board := sqliteTestBoard()
+	// Card 4 is listed before card 3 in the YAML file
+	board.Cards = append([]*Card{{ID: "4", Title: "Tag release", Column: "DONE"}}, board.Cards...)
+	board.PopulateColumnCards()
+	if err := SaveBoard(yamlPath, board); err != nil {
+		t.Fatal(err)
+	}
+
+	// Migrating keeps the order, both ways
+	dir := filepath.Join(root, ".tkan")
+	if _, err := MigrateBoard(yamlPath, dir, false); err != nil {
+		t.Fatal(err)
+	}
+	backend := NewDirectoryBackend(dir)
+	loaded, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	boardFile, _ := os.ReadFile(filepath.Join(dir, "board.yaml"))
+	position := func(id string) bool {
+		data, _ := os.ReadFile(filepath.Join(dir, "cards", id+".md"))
+		return strings.Contains(string(data), "\nposition: ")
+	}
+	if got := columnIDs(loaded, "DONE"); !slices.Equal(got, []string{"4", "3"}) {
+		t.Errorf("migrated DONE = %v, want [4 3]", got)
+	}
+	back := filepath.Join(root, "back.tkan.yaml")
+	if _, err := MigrateBoard(dir, back, false); err != nil {
+		t.Fatal(err)
+	}
+	if yamlBoard, err := LoadBoard(back); err != nil || !slices.Equal(columnIDs(yamlBoard, "DONE"), []string{"4", "3"}) {
+		t.Errorf("migrated back DONE = %v, %v", columnIDs(yamlBoard, "DONE"), err)
+	}
+
+	// Rearranging a column the way the TUI does survives a reload
+	done := &loaded.Columns[2]
+	done.Cards[0], done.Cards[1] = done.Cards[1], done.Cards[0]
+	if err := backend.SaveBoard(loaded); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded, _ := backend.LoadBoard(); !slices.Equal(columnIDs(reloaded, "DONE"), []string{"3", "4"}) {
+		t.Errorf("reordered DONE = %v, want [3 4]", columnIDs(reloaded, "DONE"))
+	}
+	if position("3") || position("4") {
+		t.Error("cards of a column in ID order shouldn't have a position")
+	}
+
+	// A card moved into a rearranged column goes last
+	done.Cards[0], done.Cards[1] = done.Cards[1], done.Cards[0]
+	if err := backend.SaveBoard(loaded); err != nil {
+		t.Fatal(err)
+	}
+	if !position("3") || !position("4") || position("2") {
+		t.Error("only the cards of a rearranged column should have a position")
+	}
+	if err := backend.MoveCard("1", "DONE"); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded, _ := backend.LoadBoard(); !slices.Equal(columnIDs(reloaded, "DONE"), []string{"4", "3", "1"}) {
+		t.Errorf("DONE after move = %v, want [4 3 1]", columnIDs(reloaded, "DONE"))
+	}
+
+	// The order is kept in the cards' files, not in the shared board.yaml
+	if data, _ := os.ReadFile(filepath.Join(dir, "board.yaml")); string(data) != string(boardFile) {
+		t.Errorf("board.yaml changed:\n%s", data)
+	}
+}
+
+func TestDirectoryBackendKeepsCardsAddedElsewhere(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".tkan")
+	backend := NewDirectoryBackend(dir)
+	if err := backend.SaveBoard(sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process (git pull, tkan add) adds a card while the board is open
+	other := NewDirectoryBackend(dir)
+	if _, err := other.CreateCard("From elsewhere", "", "TODO"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dropping a loaded card from the board removes its file; the new one stays
+	board.Cards = board.Cards[1:]
+	board.PopulateColumnCards()
+	if err := backend.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cards", "4.md")); err != nil {
+		t.Error("a card added by another process was removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cards", "1.md")); err == nil {
+		t.Error("a card dropped from the board was kept")
+	}
+}
+
+func TestMigrateBoardToDirectory(t *testing.T) {
+	root := t.TempDir()
+	project := filepath.Join(root, "app")
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatal(err)
+	}
+	yamlPath := filepath.Join(project, ".tkan.yaml")
+	if err := SaveBoard(yamlPath, sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+
+	to, err := migrateDestination(yamlPath, "dir")
+	if err != nil || to != filepath.Join(project, ".tkan") {
+		t.Fatalf("destination = %s, %v", to, err)
+	}
+	if _, err := MigrateBoard(yamlPath, to, false); err != nil {
+		t.Fatal(err)
+	}
+	// Replacing an existing board directory drops cards that are gone from the source
+	os.WriteFile(filepath.Join(to, "cards", "99.md"), []byte("---\ntitle: Stale\ncolumn: TODO\n---\n"), 0644)
+	if _, err := MigrateBoard(yamlPath, to, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(to, "cards", "99.md")); err == nil {
+		t.Error("stale card file survived --force")
+	}
+
+	// --force only replaces directories that are boards
+	notes := filepath.Join(root, "notes")
+	os.MkdirAll(notes, 0755)
+	os.WriteFile(filepath.Join(notes, "keep.txt"), []byte("keep"), 0644)
+	if _, err := MigrateBoard(yamlPath, notes, true); err == nil {
+		t.Error("migrating onto a directory that isn't a board should fail")
+	}
+	if _, err := os.Stat(filepath.Join(notes, "keep.txt")); err != nil {
+		t.Error("a directory that isn't a board was replaced")
+	}
+	if _, ok := NewFileBackend(filepath.Join(root, "myboard")).(*LocalBackend); !ok {
+		t.Error("a path without an extension should stay a YAML board")
+	}
+	os.Remove(yamlPath)
+
+	projects, err := ScanProjects(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 || projects[0].Path != to || projects[0].Name != "Big board" {
+		t.Fatalf("projects = %+v", projects)
+	}
+	if _, ok := NewFileBackend(projects[0].Path).(*DirectoryBackend); !ok {
+		t.Error("a scanned board directory should open with the directory backend")
+	}
+}
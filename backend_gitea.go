@@ -0,0 +1,328 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// giteaPageSize is the number of issues fetched per request (Gitea's default maximum)
+const giteaPageSize = 50
+
+// GiteaBackend implements Backend for Gitea and Forgejo repository projects
+// Columns are the project's own columns; cards are the issues on the project, keyed by issue number
+type GiteaBackend struct {
+	host      string // e.g. git.example.com (or http://localhost:3000)
+	owner     string
+	repo      string
+	projectID int64
+	client    *restClient
+
+	mu      sync.Mutex
+	columns map[string]int64 // Column title -> column ID, from the last LoadBoard
+}
+
+// NewGiteaBackend creates a backend for project projectID of host/owner/repo
+func NewGiteaBackend(host, owner, repo string, projectID int64) *GiteaBackend {
+	return &GiteaBackend{
+		host:      host,
+		owner:     owner,
+		repo:      repo,
+		projectID: projectID,
+		client:    newGiteaClient(giteaBaseURL(host), giteaToken(host)),
+	}
+}
+
+// ParseGiteaProjectSpec parses "host/owner/repo/project-id"
+// The host may carry a scheme (http://localhost:3000/owner/repo/1); https is assumed otherwise
+func ParseGiteaProjectSpec(spec string) (host, owner, repo string, projectID int64, err error) {
+	scheme := ""
+	if i := strings.Index(spec, "://"); i >= 0 {
+		scheme, spec = spec[:i+3], spec[i+3:]
+	}
+
+	parts := strings.Split(strings.Trim(spec, "/"), "/")
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", 0, fmt.Errorf("invalid Gitea project format. Use: host/owner/repo/project-id")
+	}
+
+	projectID, err = strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return "", "", "", 0, fmt.Errorf("invalid project id: %s", parts[3])
+	}
+	return scheme + parts[0], parts[1], parts[2], projectID, nil
+}
+
+// giteaBaseURL returns the API root for a host ("git.example.com" -> "https://git.example.com/api/v1")
+func giteaBaseURL(host string) string {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	return strings.TrimSuffix(host, "/") + "/api/v1"
+}
+
+// giteaToken returns the access token for a Gitea/Forgejo host
+// GITEA_TOKEN or FORGEJO_TOKEN win over gitea_tokens in config.yaml
+func giteaToken(host string) string {
+	for _, name := range []string{"GITEA_TOKEN", "FORGEJO_TOKEN"} {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+	cfg, _ := LoadConfig()
+	name := host
+	if i := strings.Index(name, "://"); i >= 0 {
+		name = name[i+3:]
+	}
+	return cfg.GiteaTokens[name]
+}
+
+// ProjectURL returns the web URL of the project
+func (g *GiteaBackend) ProjectURL() string {
+	base := strings.TrimSuffix(giteaBaseURL(g.host), "/api/v1")
+	return fmt.Sprintf("%s/%s/%s/projects/%d", base, g.owner, g.repo, g.projectID)
+}
+
+// giteaProject is a repository project
+type giteaProject struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	HTMLURL     string `json:"html_url"`
+}
+
+// giteaColumn is a project column
+type giteaColumn struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Sorting int    `json:"sorting"`
+}
+
+// giteaIssue is an issue as returned by the issue and project column endpoints
+type giteaIssue struct {
+	Number  int64  `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	DueDate   *time.Time `json:"due_date"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// toCard converts an issue in a column to a card
+func (i giteaIssue) toCard(column string) *Card {
+	card := &Card{
+		ID:          strconv.FormatInt(i.Number, 10),
+		Title:       i.Title,
+		Description: i.Body,
+		URL:         i.HTMLURL,
+		Column:      column,
+		CreatedAt:   i.CreatedAt,
+		ModifiedAt:  i.UpdatedAt,
+		ContentType: "Issue",
+	}
+	for _, l := range i.Labels {
+		card.Tags = append(card.Tags, l.Name)
+	}
+	var logins []string
+	for _, a := range i.Assignees {
+		logins = append(logins, a.Login)
+	}
+	if len(logins) > 0 {
+		card.Assignee = "@" + strings.Join(logins, ", @")
+	}
+	if i.DueDate != nil && !i.DueDate.IsZero() {
+		card.DueDate = i.DueDate.Format(dueDateLayout)
+	}
+	return card
+}
+
+// repoPath returns an API path under the repository
+func (g *GiteaBackend) repoPath(format string, args ...interface{}) string {
+	return fmt.Sprintf("/repos/%s/%s", url.PathEscape(g.owner), url.PathEscape(g.repo)) + fmt.Sprintf(format, args...)
+}
+
+// LoadBoard fetches the project's columns and the issues in each of them
+func (g *GiteaBackend) LoadBoard() (*Board, error) {
+	var project giteaProject
+	if _, err := g.client.do(http.MethodGet, g.repoPath("/projects/%d", g.projectID), nil, &project); err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	var columns []giteaColumn
+	if _, err := g.client.do(http.MethodGet, g.repoPath("/projects/%d/columns", g.projectID), nil, &columns); err != nil {
+		return nil, fmt.Errorf("failed to get project columns: %w", err)
+	}
+
+	sort.SliceStable(columns, func(i, j int) bool { return columns[i].Sorting < columns[j].Sorting })
+
+	board := &Board{
+		Name:        project.Title,
+		Description: project.Description,
+		URL:         project.HTMLURL,
+		Cards:       []*Card{},
+		CreatedAt:   time.Now(),
+		ModifiedAt:  time.Now(),
+	}
+	if board.URL == "" {
+		board.URL = g.ProjectURL()
+	}
+
+	ids := map[string]int64{}
+	for _, col := range columns {
+		board.Columns = append(board.Columns, Column{Name: col.Title})
+		ids[col.Title] = col.ID
+
+		for page := 1; ; page++ {
+			var issues []giteaIssue
+			path := g.repoPath("/projects/%d/columns/%d/issues?page=%d&limit=%d", g.projectID, col.ID, page, giteaPageSize)
+			if _, err := g.client.do(http.MethodGet, path, nil, &issues); err != nil {
+				return nil, fmt.Errorf("failed to get issues in column %s: %w", col.Title, err)
+			}
+			for _, issue := range issues {
+				board.Cards = append(board.Cards, issue.toCard(col.Title))
+			}
+			if len(issues) < giteaPageSize {
+				break
+			}
+		}
+	}
+
+	g.mu.Lock()
+	g.columns = ids
+	g.mu.Unlock()
+
+	board.PopulateColumnCards()
+	return board, nil
+}
+
+// column returns the ID and title of a project column (matched case-insensitively),
+// loading the columns if needed
+func (g *GiteaBackend) column(name string) (int64, string, error) {
+	g.mu.Lock()
+	loaded := g.columns != nil
+	g.mu.Unlock()
+	if !loaded {
+		var columns []giteaColumn
+		if _, err := g.client.do(http.MethodGet, g.repoPath("/projects/%d/columns", g.projectID), nil, &columns); err != nil {
+			return 0, "", fmt.Errorf("failed to get project columns: %w", err)
+		}
+		ids := map[string]int64{}
+		for _, col := range columns {
+			ids[col.Title] = col.ID
+		}
+		g.mu.Lock()
+		g.columns = ids
+		g.mu.Unlock()
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for title, id := range g.columns {
+		if strings.EqualFold(title, name) {
+			return id, title, nil
+		}
+	}
+	return 0, "", fmt.Errorf("project has no column %s", name)
+}
+
+// SaveBoard is a no-op for the Gitea backend (changes are immediate)
+func (g *GiteaBackend) SaveBoard(board *Board) error {
+	return nil
+}
+
+// MoveCard moves an issue to another column of the project
+func (g *GiteaBackend) MoveCard(cardID string, toColumn string) error {
+	number, err := strconv.ParseInt(cardID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid issue number: %s", cardID)
+	}
+	columnID, _, err := g.column(toColumn)
+	if err != nil {
+		return err
+	}
+	_, err = g.client.do(http.MethodPost, g.repoPath("/projects/%d/columns/%d/issues", g.projectID, columnID),
+		map[string]interface{}{"issue_index": number}, nil)
+	return err
+}
+
+// UpdateCard updates an issue's title, body, assignees, due date and labels
+func (g *GiteaBackend) UpdateCard(card *Card) error {
+	edit := map[string]interface{}{
+		"title":     card.Title,
+		"body":      card.Description,
+		"assignees": nonNil(parseAssignees(card.Assignee)),
+	}
+	if card.DueDate == "" {
+		edit["unset_due_date"] = true
+	} else {
+		due, err := time.Parse(dueDateLayout, card.DueDate)
+		if err != nil {
+			return fmt.Errorf("invalid due date %q: %w", card.DueDate, err)
+		}
+		edit["due_date"] = due
+	}
+	if _, err := g.client.do(http.MethodPatch, g.repoPath("/issues/%s", card.ID), edit, nil); err != nil {
+		return err
+	}
+
+	_, err := g.client.do(http.MethodPut, g.repoPath("/issues/%s/labels", card.ID),
+		map[string]interface{}{"labels": nonNil(card.Tags)}, nil)
+	return err
+}
+
+// nonNil returns an empty slice for nil, so it encodes as [] rather than null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// CreateCard opens an issue in the repository and adds it to the column
+func (g *GiteaBackend) CreateCard(title, description, column string) (*Card, error) {
+	columnID, columnTitle, err := g.column(column)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue giteaIssue
+	if _, err := g.client.do(http.MethodPost, g.repoPath("/issues"),
+		map[string]interface{}{"title": title, "body": description}, &issue); err != nil {
+		return nil, err
+	}
+	if _, err := g.client.do(http.MethodPost, g.repoPath("/projects/%d/columns/%d/issues", g.projectID, columnID),
+		map[string]interface{}{"issue_index": issue.Number}, nil); err != nil {
+		return nil, fmt.Errorf("created issue #%d but failed to add it to the project: %w", issue.Number, err)
+	}
+
+	return issue.toCard(columnTitle), nil
+}
+
+// DeleteCard removes an issue from the project; the issue itself is left open
+func (g *GiteaBackend) DeleteCard(cardID string) error {
+	_, err := g.client.do(http.MethodDelete, g.repoPath("/projects/%d/issues/%s", g.projectID, cardID), nil, nil)
+	return err
+}
+
+// newGiteaClient creates a client for the API at baseURL (e.g. https://git.example.com/api/v1)
+func newGiteaClient(baseURL, token string) *restClient {
+	auth := ""
+	if token != "" {
+		auth = "token " + token
+	}
+	return newRESTClient("Gitea", baseURL, "Authorization", auth)
+}
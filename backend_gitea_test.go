@@ -0,0 +1,235 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// giteaRequest is a request received by the fake Gitea server
+type giteaRequest struct {
+	Method string
+	Path   string // Including the query string
+	Body   map[string]interface{}
+}
+
+// fakeGitea is a local Gitea API server for one project (owner/repo, project 7)
+type fakeGitea struct {
+	t        *testing.T
+	server   *httptest.Server
+	requests []giteaRequest
+	issues   map[int64][]map[string]interface{} // Column ID -> issues
+}
+
+// newFakeGitea starts a fake server that requires the token "gitea-token"
+func newFakeGitea(t *testing.T) *fakeGitea {
+	f := &fakeGitea{t: t, issues: map[int64][]map[string]interface{}{}}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// backend returns a Gitea backend talking to the fake server
+func (f *fakeGitea) backend() *GiteaBackend {
+	g := NewGiteaBackend(f.server.URL, "acme", "app", 7)
+	g.client = newGiteaClient(f.server.URL+"/api/v1", "gitea-token")
+	return g
+}
+
+// giteaIssueJSON builds an issue as returned by the API
+func giteaIssueJSON(number int64, title string, labels ...string) map[string]interface{} {
+	var labelList []map[string]interface{}
+	for _, l := range labels {
+		labelList = append(labelList, map[string]interface{}{"name": l})
+	}
+	return map[string]interface{}{
+		"number": number, "title": title, "body": "Body", "state": "open",
+		"html_url":   fmt.Sprintf("https://git.example.com/acme/app/issues/%d", number),
+		"labels":     labelList,
+		"assignees":  []map[string]interface{}{{"login": "alice"}},
+		"due_date":   "2025-03-01T00:00:00Z",
+		"created_at": "2025-01-01T00:00:00Z",
+		"updated_at": "2025-01-02T00:00:00Z",
+	}
+}
+
+func (f *fakeGitea) serve(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "token gitea-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"message": "token is required"})
+		return
+	}
+
+	req := giteaRequest{Method: r.Method, Path: r.URL.RequestURI()}
+	json.NewDecoder(r.Body).Decode(&req.Body)
+	f.requests = append(f.requests, req)
+
+	const repo = "/api/v1/repos/acme/app"
+	path := r.URL.Path
+	var respond interface{}
+	switch {
+	case r.Method == http.MethodGet && path == repo+"/projects/7":
+		respond = map[string]interface{}{"id": 7, "title": "Roadmap", "description": "Q1", "html_url": "https://git.example.com/acme/app/projects/7"}
+	case r.Method == http.MethodGet && path == repo+"/projects/7/columns":
+		respond = []map[string]interface{}{
+			{"id": 12, "title": "Done", "sorting": 2},
+			{"id": 10, "title": "Backlog", "sorting": 0},
+			{"id": 11, "title": "In Progress", "sorting": 1},
+		}
+	case r.Method == http.MethodGet && strings.HasPrefix(path, repo+"/projects/7/columns/"):
+		columnID, _ := strconv.ParseInt(strings.Split(strings.TrimPrefix(path, repo+"/projects/7/columns/"), "/")[0], 10, 64)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		issues := f.issues[columnID]
+		start, end := min((page-1)*limit, len(issues)), min(page*limit, len(issues))
+		respond = append([]map[string]interface{}{}, issues[start:end]...)
+	case r.Method == http.MethodPost && path == repo+"/issues":
+		respond = giteaIssueJSON(99, req.Body["title"].(string))
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/issues"):
+		w.WriteHeader(http.StatusCreated)
+		return
+	case r.Method == http.MethodPatch, r.Method == http.MethodPut:
+		respond = map[string]interface{}{}
+	case r.Method == http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "not found: " + path})
+		return
+	}
+	json.NewEncoder(w).Encode(respond)
+}
+
+// last returns the most recent request with the given method
+func (f *fakeGitea) last(method string) giteaRequest {
+	for i := len(f.requests) - 1; i >= 0; i-- {
+		if f.requests[i].Method == method {
+			return f.requests[i]
+		}
+	}
+	f.t.Fatalf("no %s request", method)
+	return giteaRequest{}
+}
+
+func TestParseGiteaProjectSpec(t *testing.T) {
+	host, owner, repo, id, err := ParseGiteaProjectSpec("git.example.com/acme/app/3")
+	if err != nil || host != "git.example.com" || owner != "acme" || repo != "app" || id != 3 {
+		t.Errorf("got %q %q %q %d %v", host, owner, repo, id, err)
+	}
+	if host, _, _, _, err := ParseGiteaProjectSpec("http://localhost:3000/acme/app/3"); err != nil || host != "http://localhost:3000" {
+		t.Errorf("host with scheme = %q, %v", host, err)
+	}
+	for _, bad := range []string{"acme/app/3", "git.example.com/acme/app/x", "git.example.com/acme/app/3/4"} {
+		if _, _, _, _, err := ParseGiteaProjectSpec(bad); err == nil {
+			t.Errorf("%q should be rejected", bad)
+		}
+	}
+	if got := giteaBaseURL("git.example.com"); got != "https://git.example.com/api/v1" {
+		t.Errorf("base URL = %q", got)
+	}
+}
+
+func TestGiteaLoadBoard(t *testing.T) {
+	f := newFakeGitea(t)
+	for n := int64(1); n <= giteaPageSize+1; n++ {
+		f.issues[10] = append(f.issues[10], giteaIssueJSON(n, fmt.Sprintf("Issue %d", n)))
+	}
+	f.issues[11] = []map[string]interface{}{giteaIssueJSON(100, "Working on it", "bug", "ui")}
+
+	board, err := f.backend().LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.Name != "Roadmap" || board.URL != "https://git.example.com/acme/app/projects/7" {
+		t.Errorf("board = %q %q", board.Name, board.URL)
+	}
+	var columns []string
+	for _, col := range board.Columns {
+		columns = append(columns, col.Name)
+	}
+	if strings.Join(columns, ",") != "Backlog,In Progress,Done" {
+		t.Errorf("columns = %v, want project order", columns)
+	}
+	if len(board.Columns[0].Cards) != giteaPageSize+1 {
+		t.Errorf("Backlog has %d cards, want all pages", len(board.Columns[0].Cards))
+	}
+
+	card := board.FindCard("100")
+	if card == nil {
+		t.Fatal("issue #100 missing")
+	}
+	if card.Column != "In Progress" || card.Assignee != "@alice" || card.DueDate != "2025-03-01" ||
+		strings.Join(card.Tags, ",") != "bug,ui" || card.URL != "https://git.example.com/acme/app/issues/100" {
+		t.Errorf("card = %+v", card)
+	}
+}
+
+func TestGiteaMutations(t *testing.T) {
+	f := newFakeGitea(t)
+	g := f.backend()
+
+	if err := g.MoveCard("5", "in progress"); err != nil {
+		t.Fatal(err)
+	}
+	move := f.last(http.MethodPost)
+	if move.Path != "/api/v1/repos/acme/app/projects/7/columns/11/issues" || move.Body["issue_index"] != float64(5) {
+		t.Errorf("move = %+v", move)
+	}
+	if err := g.MoveCard("5", "Nowhere"); err == nil {
+		t.Error("moving to an unknown column should fail")
+	}
+
+	card, err := g.CreateCard("New", "Desc", "done")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.ID != "99" || card.Title != "New" || card.Column != "Done" {
+		t.Errorf("created card = %+v", card)
+	}
+	if add := f.last(http.MethodPost); add.Path != "/api/v1/repos/acme/app/projects/7/columns/12/issues" || add.Body["issue_index"] != float64(99) {
+		t.Errorf("add to column = %+v", add)
+	}
+
+	card.Title = "Renamed"
+	card.Tags = []string{"bug"}
+	card.Assignee = "@alice, @bob"
+	card.DueDate = ""
+	if err := g.UpdateCard(card); err != nil {
+		t.Fatal(err)
+	}
+	edit := f.last(http.MethodPatch)
+	if edit.Path != "/api/v1/repos/acme/app/issues/99" || edit.Body["title"] != "Renamed" || edit.Body["unset_due_date"] != true {
+		t.Errorf("edit = %+v", edit)
+	}
+	if assignees, _ := edit.Body["assignees"].([]interface{}); len(assignees) != 2 {
+		t.Errorf("assignees = %v", edit.Body["assignees"])
+	}
+	if labels := f.last(http.MethodPut); labels.Path != "/api/v1/repos/acme/app/issues/99/labels" {
+		t.Errorf("labels = %+v", labels)
+	}
+
+	if err := g.DeleteCard("99"); err != nil {
+		t.Fatal(err)
+	}
+	if del := f.last(http.MethodDelete); del.Path != "/api/v1/repos/acme/app/projects/7/issues/99" {
+		t.Errorf("delete = %+v", del)
+	}
+}
+
+func TestGiteaAPIError(t *testing.T) {
+	f := newFakeGitea(t)
+	g := f.backend()
+	g.client = newGiteaClient(f.server.URL+"/api/v1", "wrong")
+
+	_, err := g.LoadBoard()
+	var apiErr *RESTAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message != "token is required" {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -1,35 +1,110 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"os/exec"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // GitHubBackend implements a backend that uses GitHub Projects
 type GitHubBackend struct {
+	host        string // GitHub host (github.com or an Enterprise Server host)
 	owner       string // GitHub owner (user or org)
 	projectNum  int    // Project number
 	repoName    string // Repository name for the project
+	gql         GraphQLClient
+
+	// Create new cards as issues in the project's repository instead of draft issues
+	createAsIssues bool
+
+	// Owner and project details, cached by getProjectInfo
+	ownerType   string   // "User" or "Organization"
+	projectURL  string   // Project URL as reported by the API
+	linkedRepos []string // owner/name of repositories linked to the project
+
+	// Project schema, fetched on first use
+	repositoryID  string
+	projectID     string
+	statusFieldID string
+	statusOptions []githubFieldOption
+
+	// Guards the project details and schema; queued moves load them from their own goroutines
+	schemaMu sync.Mutex
+
+	// Latest queued move per card ID, see QueueMove
+	movesMu      sync.Mutex
+	pendingMoves map[string]int
+	moveSeq      int
+
+	// Journal for mutations made while GitHub is unreachable (nil disables it)
+	outbox *Outbox
+
+	// Save each loaded board to the local cache (see CachedBoard)
+	cacheBoards bool
+}
+
+// githubFieldOption is an option of a single-select project field
+type githubFieldOption struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
-// GitHubProjectItem represents an item from GitHub Projects
-type GitHubProjectItem struct {
-	ID        string                 `json:"id"`
-	Type      string                 `json:"type"`
-	Content   map[string]interface{} `json:"content"`
-	FieldValues map[string]interface{} `json:"fieldValues"`
+// githubProjectInfo is the project metadata returned by getProjectInfo
+type githubProjectInfo struct {
+	ID               string                     `json:"id"`
+	Title            string                     `json:"title"`
+	ShortDescription string                     `json:"shortDescription"`
+	URL              string                     `json:"url"`
+	Repositories     githubRepositoryConnection `json:"repositories"`
+}
+
+// githubRepositoryConnection is a page of a project's linked repositories
+type githubRepositoryConnection struct {
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"nodes"`
 }
 
 // NewGitHubBackend creates a new GitHub Projects backend
-func NewGitHubBackend(owner string, projectNum int, repoName string) *GitHubBackend {
+// host is "github.com" or a GitHub Enterprise Server host ("" means github.com)
+func NewGitHubBackend(host, owner string, projectNum int, repoName string) *GitHubBackend {
+	if host == "" {
+		host = defaultGitHubHost
+	}
 	return &GitHubBackend{
+		host:       host,
 		owner:      owner,
 		projectNum: projectNum,
 		repoName:   repoName,
+		gql:        NewGitHubScheduler(NewGraphQLClient(host)),
+	}
+}
+
+// ParseGitHubProjectSpec parses "owner/project-number" or "owner/repo/project-number"
+func ParseGitHubProjectSpec(spec string) (owner, repoName string, projectNum int, err error) {
+	parts := strings.Split(spec, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", "", 0, fmt.Errorf("invalid GitHub project format. Use: owner/project-number or owner/repo/project-number")
+	}
+
+	owner = parts[0]
+	projectNumStr := parts[len(parts)-1]
+	if len(parts) == 3 {
+		repoName = parts[1]
+	}
+
+	projectNum, err = strconv.Atoi(projectNumStr)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid project number: %s", projectNumStr)
 	}
+
+	return owner, repoName, projectNum, nil
 }
 
 // GitHubProjectInfo represents a GitHub project from the list
@@ -39,39 +114,75 @@ type GitHubProjectInfo struct {
 	Owner  string `json:"owner"`
 }
 
-// ListGitHubProjects lists all GitHub projects accessible to the current user
-func ListGitHubProjects(owner string) ([]GitHubProjectInfo, error) {
-	// Use gh CLI to list projects
-	var cmd *exec.Cmd
-	if owner != "" {
-		cmd = exec.Command("gh", "project", "list", "--owner", owner, "--format", "json", "--limit", "100")
-	} else {
-		cmd = exec.Command("gh", "project", "list", "--owner", "@me", "--format", "json", "--limit", "100")
+// githubProjectNodes is the project list selection shared by githubListProjectsQuery's branches
+const githubProjectNodes = `projectsV2(first: 100) {
+	nodes {
+		number
+		title
+		owner {
+			... on User { login }
+			... on Organization { login }
+		}
 	}
+}`
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("failed to list projects: %v (output: %s)", err, string(output))
+// githubListProjectsQuery lists the projects of a user or organization
+const githubListProjectsQuery = `
+query($owner: String!) {
+	repositoryOwner(login: $owner) {
+		... on ProjectV2Owner { ` + githubProjectNodes + ` }
 	}
+}`
 
-	// Parse JSON output
-	var rawProjects struct {
-		Projects []struct {
+// githubListViewerProjectsQuery lists the authenticated user's projects
+const githubListViewerProjectsQuery = `
+query {
+	viewer { ` + githubProjectNodes + ` }
+}`
+
+// githubProjectList mirrors the projectsV2 connection in the list queries
+type githubProjectList struct {
+	ProjectsV2 struct {
+		Nodes []struct {
 			Number int    `json:"number"`
 			Title  string `json:"title"`
 			Owner  struct {
 				Login string `json:"login"`
 			} `json:"owner"`
-		} `json:"projects"`
-	}
+		} `json:"nodes"`
+	} `json:"projectsV2"`
+}
 
-	if err := json.Unmarshal(output, &rawProjects); err != nil {
-		return nil, fmt.Errorf("failed to parse project list: %v", err)
+// ListGitHubProjects lists all GitHub projects accessible to the current user
+// owner "" or "@me" lists the authenticated user's own projects
+func ListGitHubProjects(host, owner string) ([]GitHubProjectInfo, error) {
+	gql := NewGitHubScheduler(NewGraphQLClient(host))
+
+	var list *githubProjectList
+	if owner == "" || owner == "@me" {
+		var result struct {
+			Viewer githubProjectList `json:"viewer"`
+		}
+		if err := gql.Do(githubListViewerProjectsQuery, nil, &result); err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+		list = &result.Viewer
+	} else {
+		var result struct {
+			RepositoryOwner *githubProjectList `json:"repositoryOwner"`
+		}
+		if err := gql.Do(githubListProjectsQuery, map[string]interface{}{"owner": owner}, &result); err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+		if result.RepositoryOwner == nil {
+			return nil, fmt.Errorf("owner not found: %s", owner)
+		}
+		list = result.RepositoryOwner
 	}
 
 	// Convert to our format
-	projects := make([]GitHubProjectInfo, 0, len(rawProjects.Projects))
-	for _, p := range rawProjects.Projects {
+	projects := make([]GitHubProjectInfo, 0, len(list.ProjectsV2.Nodes))
+	for _, p := range list.ProjectsV2.Nodes {
 		// Use the actual owner login from the response
 		actualOwner := p.Owner.Login
 		if actualOwner == "" {
@@ -96,31 +207,22 @@ func (g *GitHubBackend) LoadBoard() (*Board, error) {
 	}
 
 	// Get all items in the project
-	items, err := g.getProjectItems()
+	items, err := g.getProjectItems(projectInfo.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project items: %v", err)
 	}
 
 	// Create board with standard columns
 	boardName := "GitHub Project"
-	if title, ok := projectInfo["title"].(string); ok {
-		boardName = title
-	}
-	boardDesc := ""
-	if desc, ok := projectInfo["shortDescription"].(string); ok {
-		boardDesc = desc
+	if projectInfo.Title != "" {
+		boardName = projectInfo.Title
 	}
-
-	// Construct GitHub project URL
-	// Format: https://github.com/users/OWNER/projects/NUM (for users)
-	//     or: https://github.com/orgs/OWNER/projects/NUM (for orgs)
-	// We'll default to users, but both formats work
-	boardURL := fmt.Sprintf("https://github.com/users/%s/projects/%d", g.owner, g.projectNum)
+	boardDesc := projectInfo.ShortDescription
 
 	board := &Board{
 		Name:        boardName,
 		Description: boardDesc,
-		URL:         boardURL,
+		URL:         g.ProjectURL(),
 		Columns: []Column{
 			{Name: "BACKLOG"},
 			{Name: "TODO"},
@@ -152,179 +254,133 @@ func (g *GitHubBackend) LoadBoard() (*Board, error) {
 		}
 	}
 
+	if g.cacheBoards {
+		// Best effort: a failed write only costs the next launch its instant start
+		SaveBoardCache(g.boardKey(), board)
+	}
+
+	// Keep changes that haven't reached GitHub yet
+	if g.outbox != nil {
+		ApplyOutbox(board, g.outbox.Pending(g.boardKey()))
+	}
+
 	return board, nil
 }
 
-// getProjectInfo fetches basic project information
-func (g *GitHubBackend) getProjectInfo() (map[string]interface{}, error) {
-	query := fmt.Sprintf(`
-	{
-		user(login: "%s") {
-			projectV2(number: %d) {
+// githubProjectQuery looks up a user or organization project by number
+// repositoryOwner resolves either kind of owner, and __typename tells which one it is
+const githubProjectQuery = `
+query($owner: String!, $number: Int!) {
+	repositoryOwner(login: $owner) {
+		__typename
+		... on ProjectV2Owner {
+			projectV2(number: $number) {
+				id
 				title
 				shortDescription
-				id
+				url
+				repositories(first: 100) { pageInfo { hasNextPage endCursor } nodes { nameWithOwner } }
 			}
 		}
-	}`, g.owner, g.projectNum)
+	}
+}`
 
-	cmd := exec.Command("gh", "api", "graphql", "-f", fmt.Sprintf("query=%s", query))
-	output, err := cmd.Output()
-	if err != nil {
-		// Try organization if user fails
-		query = fmt.Sprintf(`
-		{
-			organization(login: "%s") {
-				projectV2(number: %d) {
-					title
-					shortDescription
-					id
-				}
-			}
-		}`, g.owner, g.projectNum)
-		
-		cmd = exec.Command("gh", "api", "graphql", "-f", fmt.Sprintf("query=%s", query))
-		output, err = cmd.Output()
-		if err != nil {
-			return nil, err
+// githubProjectRepositoriesQuery fetches a further page of a project's linked repositories
+const githubProjectRepositoriesQuery = `
+query($id: ID!, $cursor: String) {
+	node(id: $id) {
+		... on ProjectV2 {
+			repositories(first: 100, after: $cursor) { pageInfo { hasNextPage endCursor } nodes { nameWithOwner } }
 		}
 	}
+}`
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(output, &result); err != nil {
+// getProjectInfo fetches basic project information and caches the owner type,
+// project ID, URL and linked repositories
+func (g *GitHubBackend) getProjectInfo() (*githubProjectInfo, error) {
+	project, ownerType, err := g.fetchProjectInfo()
+	if err != nil {
 		return nil, err
 	}
 
-	// Extract project data from nested structure
-	data := result["data"].(map[string]interface{})
-	var project map[string]interface{}
-	
-	if userOrOrg, ok := data["user"]; ok && userOrOrg != nil {
-		project = userOrOrg.(map[string]interface{})["projectV2"].(map[string]interface{})
-	} else if userOrOrg, ok := data["organization"]; ok && userOrOrg != nil {
-		project = userOrOrg.(map[string]interface{})["projectV2"].(map[string]interface{})
-	}
-
-	if project == nil {
-		return nil, fmt.Errorf("project not found")
-	}
-
+	g.schemaMu.Lock()
+	defer g.schemaMu.Unlock()
+	g.cacheProjectInfo(project, ownerType)
 	return project, nil
 }
 
-// getProjectItems fetches all items in the project
-func (g *GitHubBackend) getProjectItems() ([]GitHubProjectItem, error) {
-	// Use gh CLI to list items
-	cmd := exec.Command("gh", "project", "item-list", 
-		fmt.Sprintf("%d", g.projectNum),
-		"--owner", g.owner,
-		"--format", "json",
-		"--limit", "100")
-	
-	output, err := cmd.Output()
+// fetchProjectInfo fetches basic project information and its owner's type
+func (g *GitHubBackend) fetchProjectInfo() (*githubProjectInfo, string, error) {
+	var result struct {
+		RepositoryOwner *struct {
+			Typename  string             `json:"__typename"`
+			ProjectV2 *githubProjectInfo `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+	err := g.gql.Do(githubProjectQuery, map[string]interface{}{
+		"owner":  g.owner,
+		"number": g.projectNum,
+	}, &result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list project items: %v", err)
+		return nil, "", err
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(output, &result); err != nil {
-		return nil, err
+	if result.RepositoryOwner == nil {
+		return nil, "", fmt.Errorf("owner not found: %s", g.owner)
 	}
-
-	// Parse items from the response
-	itemsRaw, ok := result["items"].([]interface{})
-	if !ok {
-		return []GitHubProjectItem{}, nil
+	if result.RepositoryOwner.ProjectV2 == nil {
+		return nil, "", fmt.Errorf("project not found: %s/%d", g.owner, g.projectNum)
 	}
 
-	items := []GitHubProjectItem{}
-	for _, itemRaw := range itemsRaw {
-		item, ok := itemRaw.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		
-		// Safely extract ID
-		id, _ := item["id"].(string)
-		if id == "" {
-			continue
+	// Large organization projects can link more repositories than fit in one page
+	project := result.RepositoryOwner.ProjectV2
+	for page := project.Repositories.PageInfo; page.HasNextPage && page.EndCursor != ""; {
+		var more struct {
+			Node *struct {
+				Repositories githubRepositoryConnection `json:"repositories"`
+			} `json:"node"`
 		}
-		
-		ghItem := GitHubProjectItem{
-			ID:          id,
-			Type:        "DraftIssue", // Default type
-			Content:     map[string]interface{}{},
-			FieldValues: map[string]interface{}{},
+		err := g.gql.Do(githubProjectRepositoriesQuery, map[string]interface{}{
+			"id":     project.ID,
+			"cursor": page.EndCursor,
+		}, &more)
+		if err != nil {
+			return nil, "", err
 		}
-
-		// Extract content
-		if content, ok := item["content"].(map[string]interface{}); ok {
-			ghItem.Content = content
-			// Get the actual type from content
-			if itemType, ok := content["type"].(string); ok {
-				ghItem.Type = itemType
-			}
+		if more.Node == nil {
+			return nil, "", fmt.Errorf("project not found: %s", project.ID)
 		}
-
-		// Extract field values (if present)
-		if fields, ok := item["fieldValues"].(map[string]interface{}); ok {
-			ghItem.FieldValues = fields
-		}
-
-		items = append(items, ghItem)
+		project.Repositories.Nodes = append(project.Repositories.Nodes, more.Node.Repositories.Nodes...)
+		page = more.Node.Repositories.PageInfo
 	}
-
-	return items, nil
+	return project, result.RepositoryOwner.Typename, nil
 }
 
-// itemToCard converts a GitHub project item to our Card format
-func (g *GitHubBackend) itemToCard(item GitHubProjectItem) *Card {
-	card := &Card{
-		ID:         item.ID,
-		CreatedAt:  time.Now(),
-		ModifiedAt: time.Now(),
-	}
-
-	// Extract title and body from content
-	if title, ok := item.Content["title"].(string); ok {
-		card.Title = title
-	}
-	if body, ok := item.Content["body"].(string); ok {
-		card.Description = body
-	}
-
-	// Extract URL (for issues/PRs linked to the item)
-	if url, ok := item.Content["url"].(string); ok {
-		card.URL = url
+// cacheProjectInfo stores the details getProjectInfo fetched; the caller holds schemaMu
+func (g *GitHubBackend) cacheProjectInfo(project *githubProjectInfo, ownerType string) {
+	g.ownerType = ownerType
+	g.projectID = project.ID
+	g.projectURL = project.URL
+	g.linkedRepos = nil
+	for _, repo := range project.Repositories.Nodes {
+		g.linkedRepos = append(g.linkedRepos, repo.NameWithOwner)
 	}
+}
 
-	// Map Status field to our Column
-	if status, ok := item.FieldValues["Status"].(string); ok {
-		card.Column = g.mapStatusToColumn(status)
-	} else {
-		card.Column = "BACKLOG" // Default column
-	}
+// ProjectURL returns the project's web URL
+// Organization projects live under /orgs/, user projects under /users/
+func (g *GitHubBackend) ProjectURL() string {
+	g.schemaMu.Lock()
+	defer g.schemaMu.Unlock()
 
-	// Extract other fields if they exist
-	if assignee, ok := item.FieldValues["Assignees"].(string); ok {
-		card.Assignee = assignee
-	}
-	if dueDate, ok := item.FieldValues["Target Date"].(string); ok {
-		card.DueDate = dueDate
+	if g.projectURL != "" {
+		return g.projectURL
 	}
-
-	// Extract labels as tags
-	if labels, ok := item.Content["labels"].([]interface{}); ok {
-		for _, label := range labels {
-			if labelMap, ok := label.(map[string]interface{}); ok {
-				if name, ok := labelMap["name"].(string); ok {
-					card.Tags = append(card.Tags, name)
-				}
-			}
-		}
+	kind := "users"
+	if g.ownerType == "Organization" {
+		kind = "orgs"
 	}
-
-	return card
+	return fmt.Sprintf("https://%s/%s/%s/projects/%d", g.host, kind, g.owner, g.projectNum)
 }
 
 // mapStatusToColumn maps GitHub Project Status to our column names
@@ -375,108 +431,227 @@ func (g *GitHubBackend) SaveBoard(board *Board) error {
 	return nil
 }
 
-// MoveCard moves a card to a different column in GitHub
-func (g *GitHubBackend) MoveCard(cardID string, toColumn string) error {
-	status := g.mapColumnToStatus(toColumn)
-	
-	// Build GraphQL mutation to update the Status field
-	query := fmt.Sprintf(`
-	mutation {
-		updateProjectV2ItemFieldValue(input: {
-			projectId: "%s"
-			itemId: "%s"
-			fieldId: "%s"
-			value: {
-				singleSelectOptionId: "%s"
-			}
-		}) {
-			projectV2Item {
-				id
-			}
-		}
-	}`, g.getProjectID(), cardID, g.getStatusFieldID(), g.getStatusOptionID(status))
+// githubUpdateStatusMutation sets a project item's single-select field value
+const githubUpdateStatusMutation = `
+mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!, $optionId: String!) {
+	updateProjectV2ItemFieldValue(input: {
+		projectId: $projectId
+		itemId: $itemId
+		fieldId: $fieldId
+		value: { singleSelectOptionId: $optionId }
+	}) {
+		projectV2Item { id }
+	}
+}`
+
+// moveCard moves a card to a different column in GitHub
+func (g *GitHubBackend) moveCard(cardID string, toColumn string) error {
+	if err := g.loadProjectSchema(); err != nil {
+		return err
+	}
 
-	cmd := exec.Command("gh", "api", "graphql", "-f", fmt.Sprintf("query=%s", query))
-	_, err := cmd.Output()
-	return err
+	optionID := g.getStatusOptionID(toColumn)
+	if optionID == "" {
+		return fmt.Errorf("project has no Status option for column %s", toColumn)
+	}
+
+	return g.gql.Do(githubUpdateStatusMutation, map[string]interface{}{
+		"projectId": g.getProjectID(),
+		"itemId":    cardID,
+		"fieldId":   g.getStatusFieldID(),
+		"optionId":  optionID,
+	}, nil)
 }
 
-// UpdateCard updates a card's details in GitHub
-func (g *GitHubBackend) UpdateCard(card *Card) error {
-	// For draft issues, we can update title and body
-	query := fmt.Sprintf(`
-	mutation {
-		updateProjectV2DraftIssue(input: {
-			draftIssueId: "%s"
-			title: "%s"
-			body: "%s"
-		}) {
-			draftIssue {
-				id
-			}
+// githubAddDraftIssueMutation adds a draft issue to a project
+const githubAddDraftIssueMutation = `
+mutation($projectId: ID!, $title: String!, $body: String) {
+	addProjectV2DraftIssue(input: {
+		projectId: $projectId
+		title: $title
+		body: $body
+	}) {
+		projectItem {
+			id
+			content { ... on DraftIssue { id } }
 		}
-	}`, card.ID, strings.ReplaceAll(card.Title, "\"", "\\\""), 
-		strings.ReplaceAll(card.Description, "\"", "\\\""))
+	}
+}`
 
-	cmd := exec.Command("gh", "api", "graphql", "-f", fmt.Sprintf("query=%s", query))
-	_, err := cmd.Output()
-	return err
-}
+// createCard creates a new draft issue in the project
+// With createAsIssues set, it creates an issue in the repository instead
+func (g *GitHubBackend) createCard(title, description, column string) (*Card, error) {
+	if err := g.loadProjectSchema(); err != nil {
+		return nil, err
+	}
 
-// CreateCard creates a new draft issue in the project
-func (g *GitHubBackend) CreateCard(title, description, column string) (*Card, error) {
-	// Create draft issue using gh CLI
-	cmd := exec.Command("gh", "project", "item-create",
-		fmt.Sprintf("%d", g.projectNum),
-		"--owner", g.owner,
-		"--title", title,
-		"--body", description,
-		"--format", "json")
-	
-	output, err := cmd.Output()
+	var card *Card
+	var err error
+	if g.createAsIssues {
+		card, err = g.createIssueCard(title, description)
+	} else {
+		card, err = g.createDraftCard(title, description)
+	}
 	if err != nil {
 		return nil, err
 	}
+	card.Column = column
+
+	// Set the initial column
+	if err := g.moveCard(card.ID, column); err != nil {
+		return card, fmt.Errorf("created card %s but could not set its column: %w", card.ID, err)
+	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(output, &result); err != nil {
+	return card, nil
+}
+
+// createDraftCard adds a draft issue to the project
+func (g *GitHubBackend) createDraftCard(title, description string) (*Card, error) {
+	var result struct {
+		AddProjectV2DraftIssue struct {
+			ProjectItem struct {
+				ID      string `json:"id"`
+				Content struct {
+					ID string `json:"id"`
+				} `json:"content"`
+			} `json:"projectItem"`
+		} `json:"addProjectV2DraftIssue"`
+	}
+	err := g.gql.Do(githubAddDraftIssueMutation, map[string]interface{}{
+		"projectId": g.getProjectID(),
+		"title":     title,
+		"body":      description,
+	}, &result)
+	if err != nil {
 		return nil, err
 	}
 
-	card := &Card{
-		ID:          result["id"].(string),
+	id := result.AddProjectV2DraftIssue.ProjectItem.ID
+	if id == "" {
+		return nil, fmt.Errorf("addProjectV2DraftIssue returned no item ID")
+	}
+
+	return &Card{
+		ID:          id,
 		Title:       title,
 		Description: description,
-		Column:      column,
 		CreatedAt:   time.Now(),
 		ModifiedAt:  time.Now(),
+		ContentType: "DraftIssue",
+		ContentID:   result.AddProjectV2DraftIssue.ProjectItem.Content.ID,
+	}, nil
+}
+
+// githubDeleteItemMutation removes an item from a project
+const githubDeleteItemMutation = `
+mutation($projectId: ID!, $itemId: ID!) {
+	deleteProjectV2Item(input: {
+		projectId: $projectId
+		itemId: $itemId
+	}) {
+		deletedItemId
 	}
+}`
 
-	// Set the initial column
-	if column != "BACKLOG" {
-		g.MoveCard(card.ID, column)
+// DeleteCard removes an item from the project
+// Linked issues and PRs are left untouched; draft issues are deleted
+func (g *GitHubBackend) DeleteCard(cardID string) error {
+	if err := requireSynced(cardID); err != nil {
+		return err
+	}
+	if g.getProjectID() == "" {
+		if _, err := g.getProjectInfo(); err != nil {
+			return err
+		}
 	}
 
-	return card, nil
+	return g.gql.Do(githubDeleteItemMutation, map[string]interface{}{
+		"projectId": g.getProjectID(),
+		"itemId":    cardID,
+	}, nil)
 }
 
-// DeleteCard archives a card in GitHub (moves to Archive column)
-func (g *GitHubBackend) DeleteCard(cardID string) error {
-	return g.MoveCard(cardID, "ARCHIVE")
+// githubStatusFieldQuery fetches the project's Status field and its options
+const githubStatusFieldQuery = `
+query($projectId: ID!) {
+	node(id: $projectId) {
+		... on ProjectV2 {
+			field(name: "Status") {
+				... on ProjectV2SingleSelectField {
+					id
+					options { id name }
+				}
+			}
+		}
+	}
+}`
+
+// loadProjectSchema fetches and caches the project ID and Status field options
+func (g *GitHubBackend) loadProjectSchema() error {
+	g.schemaMu.Lock()
+	defer g.schemaMu.Unlock()
+
+	if g.statusFieldID != "" {
+		return nil
+	}
+	if g.projectID == "" {
+		project, ownerType, err := g.fetchProjectInfo()
+		if err != nil {
+			return err
+		}
+		g.cacheProjectInfo(project, ownerType)
+	}
+
+	var result struct {
+		Node *struct {
+			Field *struct {
+				ID      string              `json:"id"`
+				Options []githubFieldOption `json:"options"`
+			} `json:"field"`
+		} `json:"node"`
+	}
+	if err := g.gql.Do(githubStatusFieldQuery, map[string]interface{}{"projectId": g.projectID}, &result); err != nil {
+		return err
+	}
+	if result.Node == nil || result.Node.Field == nil || result.Node.Field.ID == "" {
+		return fmt.Errorf("project has no single-select Status field")
+	}
+
+	g.statusFieldID = result.Node.Field.ID
+	g.statusOptions = result.Node.Field.Options
+	return nil
 }
 
-// Helper methods for field IDs (would need to be fetched from project schema)
+// getProjectID returns the cached project node ID (see loadProjectSchema)
 func (g *GitHubBackend) getProjectID() string {
-	// This would need to be fetched and cached
-	return ""
+	g.schemaMu.Lock()
+	defer g.schemaMu.Unlock()
+	return g.projectID
 }
 
+// getStatusFieldID returns the cached Status field ID (see loadProjectSchema)
 func (g *GitHubBackend) getStatusFieldID() string {
-	// This would need to be fetched from project fields
-	return ""
+	g.schemaMu.Lock()
+	defer g.schemaMu.Unlock()
+	return g.statusFieldID
 }
 
-func (g *GitHubBackend) getStatusOptionID(status string) string {
-	// This would need to be fetched from field options
+// getStatusOptionID returns the Status option ID for a column
+// Prefers the option named by mapColumnToStatus, then any option that maps back to the column
+func (g *GitHubBackend) getStatusOptionID(column string) string {
+	g.schemaMu.Lock()
+	defer g.schemaMu.Unlock()
+
+	status := g.mapColumnToStatus(column)
+	for _, opt := range g.statusOptions {
+		if strings.EqualFold(opt.Name, status) {
+			return opt.ID
+		}
+	}
+	for _, opt := range g.statusOptions {
+		if g.mapStatusToColumn(opt.Name) == column {
+			return opt.ID
+		}
+	}
 	return ""
-}
\ No newline at end of file
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// githubGraphQLEndpoint returns the GraphQL API endpoint for a host
+// github.com uses api.github.com; Enterprise Server hosts serve the API under /api/graphql
+func githubGraphQLEndpoint(host string) string {
+	if host == "" || host == defaultGitHubHost {
+		return "https://api.github.com/graphql"
+	}
+	return "https://" + host + "/api/graphql"
+}
+
+// GraphQLClient runs GitHub GraphQL requests
+// Implementations send variables separately from the query, so user input is
+// never interpolated into query text
+type GraphQLClient interface {
+	// Do runs a query or mutation and decodes the response's data into out
+	// out may be nil when the result is not needed
+	Do(query string, variables map[string]interface{}, out interface{}) error
+}
+
+// graphQLRequest is the JSON body of a GraphQL request
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the envelope of a GraphQL response
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors"`
+}
+
+// GraphQLError is an error reported in a GraphQL response
+// Path mixes field names and list indices, e.g. ["repository", "issues", 2]
+type GraphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Path    []any  `json:"path"`
+}
+
+func (e GraphQLError) Error() string {
+	return e.Message
+}
+
+// NewGraphQLClient returns an HTTP client for host if a token is available
+// (environment or the gh hosts file), otherwise the gh CLI client
+func NewGraphQLClient(host string) GraphQLClient {
+	if host == "" {
+		host = defaultGitHubHost
+	}
+	if token := githubToken(host); token != "" {
+		return NewHTTPGraphQLClient(githubGraphQLEndpoint(host), token)
+	}
+	return &GHCLIGraphQLClient{host: host}
+}
+
+// githubToken finds an API token for host from the environment or the gh hosts file
+// Like gh, github.com uses GITHUB_TOKEN/GH_TOKEN and Enterprise hosts use
+// GH_ENTERPRISE_TOKEN/GITHUB_ENTERPRISE_TOKEN
+func githubToken(host string) string {
+	envVars := []string{"GITHUB_TOKEN", "GH_TOKEN"}
+	if host != defaultGitHubHost {
+		envVars = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+	}
+	for _, name := range envVars {
+		if token := os.Getenv(name); token != "" {
+			return token
+		}
+	}
+	return ghHostsToken(ghHostsPath(), host)
+}
+
+// ghHostsPath returns the location of gh's hosts.yml
+func ghHostsPath() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "hosts.yml")
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", "hosts.yml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh", "hosts.yml")
+}
+
+// ghHostsToken reads the oauth_token for host from a gh hosts.yml file
+// Returns "" if the file is missing or gh keeps the token in the system keyring
+func ghHostsToken(path, host string) string {
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return ""
+	}
+	return hosts[host].OAuthToken
+}
+
+// HTTPGraphQLClient calls the GraphQL API directly over HTTP with a token
+type HTTPGraphQLClient struct {
+	rateLimitTracker
+
+	endpoint string
+	token    string
+	http     *http.Client
+}
+
+// NewHTTPGraphQLClient creates a client for the given GraphQL endpoint
+func NewHTTPGraphQLClient(endpoint, token string) *HTTPGraphQLClient {
+	return &HTTPGraphQLClient{
+		endpoint: endpoint,
+		token:    token,
+		http:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Do runs a query or mutation and decodes the response's data into out
+func (c *HTTPGraphQLClient) Do(query string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode GraphQL request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("GitHub API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read GitHub API response: %w", err)
+	}
+
+	c.record(resp.Header)
+	if resp.StatusCode != http.StatusOK {
+		return apiErrorFromResponse(resp.StatusCode, resp.Status, resp.Header, apiErrorMessage(data))
+	}
+
+	return decodeGraphQLResponse(data, out)
+}
+
+// apiErrorMessage extracts the message from a REST-style error body
+func apiErrorMessage(data []byte) string {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(data, &apiErr) != nil {
+		return ""
+	}
+	return apiErr.Message
+}
+
+// GHCLIGraphQLClient runs GraphQL requests through `gh api graphql`
+// Used when no token is available, relying on gh's own authentication
+type GHCLIGraphQLClient struct {
+	rateLimitTracker
+
+	host string // Passed as --hostname
+}
+
+// Do runs a query or mutation and decodes the response's data into out
+func (c *GHCLIGraphQLClient) Do(query string, variables map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode GraphQL request: %w", err)
+	}
+
+	// --include prints the status line and headers before the body, for rate-limit tracking
+	cmd := exec.Command("gh", "api", "graphql", "--include", "--hostname", c.host, "--input", "-")
+	cmd.Stdin = bytes.NewReader(body)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, runErr := cmd.Output()
+	statusCode, status, header, output := splitGHIncludeOutput(output)
+	c.record(header)
+	if statusCode != 0 && statusCode != http.StatusOK {
+		return apiErrorFromResponse(statusCode, status, header, apiErrorMessage(output))
+	}
+
+	// gh exits non-zero on GraphQL errors but still prints the response
+	if runErr != nil {
+		var resp graphQLResponse
+		if json.Unmarshal(output, &resp) != nil || len(resp.Errors) == 0 {
+			return fmt.Errorf("gh api graphql: %s", strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	return decodeGraphQLResponse(output, out)
+}
+
+// splitGHIncludeOutput separates the status line and headers printed by
+// `gh api --include` from the response body
+// Output without a status line is returned unchanged as the body
+func splitGHIncludeOutput(output []byte) (statusCode int, status string, header http.Header, body []byte) {
+	header = http.Header{}
+	if !bytes.HasPrefix(output, []byte("HTTP/")) {
+		return 0, "", header, output
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(bytes.NewReader(output)))
+	statusLine, err := reader.ReadLine()
+	if err != nil {
+		return 0, "", header, output
+	}
+	if _, rest, ok := strings.Cut(statusLine, " "); ok {
+		status = rest
+		code, _, _ := strings.Cut(rest, " ")
+		statusCode, _ = strconv.Atoi(code)
+	}
+	mime, err := reader.ReadMIMEHeader()
+	if err != nil && len(mime) == 0 {
+		return statusCode, status, header, nil
+	}
+	header = http.Header(mime)
+
+	body, _ = io.ReadAll(reader.R)
+	return statusCode, status, header, body
+}
+
+// decodeGraphQLResponse returns the response's errors, or decodes its data into out
+func decodeGraphQLResponse(data []byte, out interface{}) error {
+	var resp graphQLResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("failed to parse GraphQL response: %w", err)
+	}
+
+	if len(resp.Errors) > 0 {
+		errs := make([]error, len(resp.Errors))
+		for i, e := range resp.Errors {
+			errs[i] = e
+		}
+		return errors.Join(errs...)
+	}
+
+	if out == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return fmt.Errorf("failed to decode GraphQL data: %w", err)
+	}
+	return nil
+}
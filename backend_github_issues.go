@@ -0,0 +1,519 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// githubItemContentQuery looks up the content behind a project item
+const githubItemContentQuery = `
+query($itemId: ID!) {
+	node(id: $itemId) {
+		... on ProjectV2Item {
+			type
+			content {
+				... on DraftIssue { id }
+				... on Issue { id }
+				... on PullRequest { id }
+			}
+		}
+	}
+}`
+
+// githubUpdateDraftIssueMutation updates a draft issue's title, body and assignees
+const githubUpdateDraftIssueMutation = `
+mutation($draftIssueId: ID!, $title: String!, $body: String!, $assigneeIds: [ID!]) {
+	updateProjectV2DraftIssue(input: {
+		draftIssueId: $draftIssueId
+		title: $title
+		body: $body
+		assigneeIds: $assigneeIds
+	}) {
+		draftIssue { id }
+	}
+}`
+
+// githubUpdateIssueMutation updates an issue's title, body, labels and assignees
+const githubUpdateIssueMutation = `
+mutation($id: ID!, $title: String!, $body: String!, $labelIds: [ID!], $assigneeIds: [ID!]) {
+	updateIssue(input: {
+		id: $id
+		title: $title
+		body: $body
+		labelIds: $labelIds
+		assigneeIds: $assigneeIds
+	}) {
+		issue { id }
+	}
+}`
+
+// githubUpdatePullRequestMutation updates a pull request's title, body, labels and assignees
+const githubUpdatePullRequestMutation = `
+mutation($id: ID!, $title: String!, $body: String!, $labelIds: [ID!], $assigneeIds: [ID!]) {
+	updatePullRequest(input: {
+		pullRequestId: $id
+		title: $title
+		body: $body
+		labelIds: $labelIds
+		assigneeIds: $assigneeIds
+	}) {
+		pullRequest { id }
+	}
+}`
+
+// githubContentLabelsQuery fetches a page of the labels on an issue or PR
+const githubContentLabelsQuery = `
+query($id: ID!, $cursor: String) {
+	node(id: $id) {
+		... on Issue { labels(first: 100, after: $cursor) { pageInfo { hasNextPage endCursor } nodes { id name } } }
+		... on PullRequest { labels(first: 100, after: $cursor) { pageInfo { hasNextPage endCursor } nodes { id name } } }
+	}
+}`
+
+// githubRepositoryLabelsQuery fetches a page of the labels of the repository an issue or PR belongs to
+const githubRepositoryLabelsQuery = `
+query($id: ID!, $cursor: String) {
+	node(id: $id) {
+		... on Issue { repository { labels(first: 100, after: $cursor) { pageInfo { hasNextPage endCursor } nodes { id name } } } }
+		... on PullRequest { repository { labels(first: 100, after: $cursor) { pageInfo { hasNextPage endCursor } nodes { id name } } } }
+	}
+}`
+
+// githubLabelConnection is a page of labels in a GraphQL response
+type githubLabelConnection struct {
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []githubLabel `json:"nodes"`
+}
+
+// githubLabel is a label's node ID and name
+type githubLabel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// githubUserQuery looks up a user's node ID by login
+const githubUserQuery = `
+query($login: String!) {
+	user(login: $login) { id }
+}`
+
+// updateCard updates a card's details in GitHub
+// Draft issues get title, body and assignees; issues and PRs also get labels from Tags,
+// which are only sent when they differ from the labels the issue or PR has
+func (g *GitHubBackend) updateCard(card *Card) error {
+	if card.ContentType == "" || card.ContentID == "" {
+		if err := g.resolveContent(card); err != nil {
+			return err
+		}
+	}
+
+	assigneeIDs, err := g.resolveAssigneeIDs(card.Assignee)
+	if err != nil {
+		return err
+	}
+
+	switch card.ContentType {
+	case "DraftIssue":
+		return g.gql.Do(githubUpdateDraftIssueMutation, map[string]interface{}{
+			"draftIssueId": card.ContentID,
+			"title":        card.Title,
+			"body":         card.Description,
+			"assigneeIds":  assigneeIDs,
+		}, nil)
+
+	case "Issue", "PullRequest":
+		variables := map[string]interface{}{
+			"id":          card.ContentID,
+			"title":       card.Title,
+			"body":        card.Description,
+			"assigneeIds": assigneeIDs,
+		}
+		current, err := contentLabels(g.gql, githubContentLabelsQuery, card.ContentID)
+		if err != nil {
+			return err
+		}
+		if !sameLabels(current, card.Tags) {
+			labelIDs, err := g.resolveLabelIDs(card.ContentID, card.Tags)
+			if err != nil {
+				return err
+			}
+			variables["labelIds"] = labelIDs
+		}
+
+		mutation := githubUpdateIssueMutation
+		if card.ContentType == "PullRequest" {
+			mutation = githubUpdatePullRequestMutation
+		}
+		return g.gql.Do(mutation, variables, nil)
+	}
+
+	return fmt.Errorf("cannot edit %s items", card.ContentType)
+}
+
+// resolveContent fills in the content type and node ID of a card from its project item
+func (g *GitHubBackend) resolveContent(card *Card) error {
+	var result struct {
+		Node *struct {
+			Type    string `json:"type"`
+			Content *struct {
+				ID string `json:"id"`
+			} `json:"content"`
+		} `json:"node"`
+	}
+	if err := g.gql.Do(githubItemContentQuery, map[string]interface{}{"itemId": card.ID}, &result); err != nil {
+		return err
+	}
+	if result.Node == nil || result.Node.Content == nil {
+		return fmt.Errorf("project item not found: %s", card.ID)
+	}
+
+	card.ContentType = githubItemType(result.Node.Type)
+	card.ContentID = result.Node.Content.ID
+	return nil
+}
+
+// resolveLabelIDs maps tag names to label IDs in the repository of an issue or PR
+// Matching is case-insensitive; tags without a matching label are an error
+func (g *GitHubBackend) resolveLabelIDs(contentID string, tags []string) ([]string, error) {
+	ids := []string{}
+	if len(tags) == 0 {
+		return ids, nil
+	}
+
+	labels, err := contentLabels(g.gql, githubRepositoryLabelsQuery, contentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, tag := range tags {
+		found := false
+		for _, label := range labels {
+			if strings.EqualFold(label.Name, tag) {
+				ids = append(ids, label.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no such label in repository: %s", strings.Join(missing, ", "))
+	}
+
+	return ids, nil
+}
+
+// contentLabels runs githubContentLabelsQuery or githubRepositoryLabelsQuery for an
+// issue or PR, following pagination cursors
+func contentLabels(gql GraphQLClient, query, contentID string) ([]githubLabel, error) {
+	var labels []githubLabel
+	cursor := ""
+	for {
+		variables := map[string]interface{}{"id": contentID}
+		if cursor != "" {
+			variables["cursor"] = cursor
+		}
+
+		var result struct {
+			Node *struct {
+				Labels     githubLabelConnection `json:"labels"`
+				Repository struct {
+					Labels githubLabelConnection `json:"labels"`
+				} `json:"repository"`
+			} `json:"node"`
+		}
+		if err := gql.Do(query, variables, &result); err != nil {
+			return nil, err
+		}
+		if result.Node == nil {
+			return nil, fmt.Errorf("issue or pull request not found: %s", contentID)
+		}
+
+		page := result.Node.Labels
+		if query == githubRepositoryLabelsQuery {
+			page = result.Node.Repository.Labels
+		}
+		labels = append(labels, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return labels, nil
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+}
+
+// sameLabels reports whether labels and tags name the same labels, ignoring case and order
+func sameLabels(labels []githubLabel, tags []string) bool {
+	if len(labels) != len(tags) {
+		return false
+	}
+	for _, tag := range tags {
+		if !slices.ContainsFunc(labels, func(l githubLabel) bool { return strings.EqualFold(l.Name, tag) }) {
+			return false
+		}
+	}
+	for _, label := range labels {
+		if !slices.ContainsFunc(tags, func(tag string) bool { return strings.EqualFold(label.Name, tag) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveAssigneeIDs maps an assignee string like "@alice, @bob" to user IDs
+func (g *GitHubBackend) resolveAssigneeIDs(assignee string) ([]string, error) {
+	ids := []string{}
+	for _, login := range parseAssignees(assignee) {
+		var result struct {
+			User *struct {
+				ID string `json:"id"`
+			} `json:"user"`
+		}
+		if err := g.gql.Do(githubUserQuery, map[string]interface{}{"login": login}, &result); err != nil {
+			return nil, err
+		}
+		if result.User == nil {
+			return nil, fmt.Errorf("no such GitHub user: %s", login)
+		}
+		ids = append(ids, result.User.ID)
+	}
+	return ids, nil
+}
+
+// parseAssignees splits an assignee string like "@alice, @bob" into logins
+func parseAssignees(assignee string) []string {
+	var logins []string
+	for _, part := range strings.FieldsFunc(assignee, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if login := strings.TrimPrefix(part, "@"); login != "" {
+			logins = append(logins, login)
+		}
+	}
+	return logins
+}
+
+// githubItemType converts the API's item type (DRAFT_ISSUE, ISSUE, PULL_REQUEST) to the content type name
+func githubItemType(apiType string) string {
+	switch apiType {
+	case "DRAFT_ISSUE":
+		return "DraftIssue"
+	case "ISSUE":
+		return "Issue"
+	case "PULL_REQUEST":
+		return "PullRequest"
+	}
+	return apiType
+}
+
+// githubRepositoryQuery looks up a repository's node ID
+const githubRepositoryQuery = `
+query($owner: String!, $name: String!) {
+	repository(owner: $owner, name: $name) { id }
+}`
+
+// githubCreateIssueMutation creates an issue in a repository
+const githubCreateIssueMutation = `
+mutation($repositoryId: ID!, $title: String!, $body: String) {
+	createIssue(input: {
+		repositoryId: $repositoryId
+		title: $title
+		body: $body
+	}) {
+		issue { id url }
+	}
+}`
+
+// githubAddItemMutation adds an existing issue or PR to a project
+const githubAddItemMutation = `
+mutation($projectId: ID!, $contentId: ID!) {
+	addProjectV2ItemById(input: {
+		projectId: $projectId
+		contentId: $contentId
+	}) {
+		item { id }
+	}
+}`
+
+// githubConvertDraftMutation converts a draft issue item to an issue in a repository
+const githubConvertDraftMutation = `
+mutation($itemId: ID!, $repositoryId: ID!) {
+	convertProjectV2DraftIssueItemToIssue(input: {
+		itemId: $itemId
+		repositoryId: $repositoryId
+	}) {
+		item {
+			id
+			content { ... on Issue { id url } }
+		}
+	}
+}`
+
+// targetRepository returns the owner/name of the repository new issues go to
+// That is the configured repository, or the project's only linked repository
+func (g *GitHubBackend) targetRepository() (string, error) {
+	if g.repoName != "" {
+		return g.owner + "/" + g.repoName, nil
+	}
+
+	if g.getProjectID() == "" {
+		if _, err := g.getProjectInfo(); err != nil {
+			return "", err
+		}
+	}
+	g.schemaMu.Lock()
+	repos := g.linkedRepos
+	g.schemaMu.Unlock()
+
+	switch len(repos) {
+	case 0:
+		return "", fmt.Errorf("no repository configured (use --github owner/repo/project-number)")
+	case 1:
+		return repos[0], nil
+	}
+	return "", fmt.Errorf("project is linked to several repositories (%s); pick one with --github %s/<repo>/%d",
+		strings.Join(repos, ", "), g.owner, g.projectNum)
+}
+
+// getRepositoryID returns the node ID of the target repository, caching it
+func (g *GitHubBackend) getRepositoryID() (string, error) {
+	g.schemaMu.Lock()
+	id := g.repositoryID
+	g.schemaMu.Unlock()
+	if id != "" {
+		return id, nil
+	}
+
+	repo, err := g.targetRepository()
+	if err != nil {
+		return "", err
+	}
+	repoOwner, repoName, _ := strings.Cut(repo, "/")
+
+	var result struct {
+		Repository *struct {
+			ID string `json:"id"`
+		} `json:"repository"`
+	}
+	err = g.gql.Do(githubRepositoryQuery, map[string]interface{}{
+		"owner": repoOwner,
+		"name":  repoName,
+	}, &result)
+	if err != nil {
+		return "", err
+	}
+	if result.Repository == nil {
+		return "", fmt.Errorf("repository not found: %s", repo)
+	}
+
+	g.schemaMu.Lock()
+	defer g.schemaMu.Unlock()
+	g.repositoryID = result.Repository.ID
+	return g.repositoryID, nil
+}
+
+// createIssueCard creates an issue in the configured repository and adds it to the project
+// Labels and assignees are set by a follow-up UpdateCard, like other fields CreateCard doesn't take
+func (g *GitHubBackend) createIssueCard(title, description string) (*Card, error) {
+	repoID, err := g.getRepositoryID()
+	if err != nil {
+		return nil, err
+	}
+
+	var issue struct {
+		CreateIssue struct {
+			Issue struct {
+				ID  string `json:"id"`
+				URL string `json:"url"`
+			} `json:"issue"`
+		} `json:"createIssue"`
+	}
+	err = g.gql.Do(githubCreateIssueMutation, map[string]interface{}{
+		"repositoryId": repoID,
+		"title":        title,
+		"body":         description,
+	}, &issue)
+	if err != nil {
+		return nil, err
+	}
+
+	var added struct {
+		AddProjectV2ItemByID struct {
+			Item struct {
+				ID string `json:"id"`
+			} `json:"item"`
+		} `json:"addProjectV2ItemById"`
+	}
+	err = g.gql.Do(githubAddItemMutation, map[string]interface{}{
+		"projectId": g.getProjectID(),
+		"contentId": issue.CreateIssue.Issue.ID,
+	}, &added)
+	if err != nil {
+		return nil, fmt.Errorf("created issue %s but could not add it to the project: %w", issue.CreateIssue.Issue.URL, err)
+	}
+
+	return &Card{
+		ID:          added.AddProjectV2ItemByID.Item.ID,
+		Title:       title,
+		Description: description,
+		URL:         issue.CreateIssue.Issue.URL,
+		CreatedAt:   time.Now(),
+		ModifiedAt:  time.Now(),
+		ContentType: "Issue",
+		ContentID:   issue.CreateIssue.Issue.ID,
+	}, nil
+}
+
+// ConvertToIssue converts a draft issue card to an issue in the configured repository
+// The card keeps its place in the project; its ID, content and URL are updated in place
+func (g *GitHubBackend) ConvertToIssue(card *Card) error {
+	if err := requireSynced(card.ID); err != nil {
+		return err
+	}
+	if card.ContentType == "" {
+		if err := g.resolveContent(card); err != nil {
+			return err
+		}
+	}
+	if card.ContentType != "DraftIssue" {
+		return fmt.Errorf("only draft issues can be converted (card is a %s)", card.ContentType)
+	}
+
+	repoID, err := g.getRepositoryID()
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		ConvertProjectV2DraftIssueItemToIssue struct {
+			Item struct {
+				ID      string `json:"id"`
+				Content struct {
+					ID  string `json:"id"`
+					URL string `json:"url"`
+				} `json:"content"`
+			} `json:"item"`
+		} `json:"convertProjectV2DraftIssueItemToIssue"`
+	}
+	err = g.gql.Do(githubConvertDraftMutation, map[string]interface{}{
+		"itemId":       card.ID,
+		"repositoryId": repoID,
+	}, &result)
+	if err != nil {
+		return err
+	}
+
+	item := result.ConvertProjectV2DraftIssueItemToIssue.Item
+	if item.ID != "" {
+		card.ID = item.ID
+	}
+	card.ContentType = "Issue"
+	card.ContentID = item.Content.ID
+	card.URL = item.Content.URL
+	return nil
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// githubItemsPageSize is the number of project items fetched per GraphQL request (the API maximum)
+const githubItemsPageSize = 100
+
+// GitHubProjectItem represents an item from GitHub Projects
+type GitHubProjectItem struct {
+	ID          string                      // Project item node ID
+	Type        string                      // DraftIssue, Issue or PullRequest
+	Content     GitHubItemContent           // The draft issue, issue or PR behind the item
+	FieldValues map[string]GitHubFieldValue // Keyed by field name (e.g. "Status")
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// GitHubItemContent is the draft issue, issue or pull request behind a project item
+type GitHubItemContent struct {
+	ID         string // Content node ID (differs from the project item ID)
+	Title      string
+	Body       string
+	URL        string // Empty for draft issues
+	Number     int    // Issue/PR number (0 for draft issues)
+	State      string // OPEN, CLOSED or MERGED (empty for draft issues)
+	Repository string // owner/name (empty for draft issues)
+	Assignees  []string
+	Labels     []string
+}
+
+// GitHubFieldValue is a typed project field value
+type GitHubFieldValue struct {
+	Type     string   // single_select, date, iteration, number, text or users
+	Text     string   // Option name, date (YYYY-MM-DD), iteration title or text
+	OptionID string   // Single-select option or iteration ID
+	Number   float64  // Number fields
+	Users    []string // Logins for user fields (e.g. Assignees)
+}
+
+// githubItemsQuery fetches one page of project items with typed field values
+const githubItemsQuery = `
+query($projectId: ID!, $first: Int!, $cursor: String) {
+	node(id: $projectId) {
+		... on ProjectV2 {
+			items(first: $first, after: $cursor) {
+				pageInfo { hasNextPage endCursor }
+				nodes {
+					id
+					type
+					createdAt
+					updatedAt
+					content {
+						... on DraftIssue {
+							id title body
+							assignees(first: 10) { nodes { login } }
+						}
+						... on Issue {
+							id title body url number state
+							repository { nameWithOwner }
+							assignees(first: 10) { nodes { login } }
+							labels(first: 100) { pageInfo { hasNextPage endCursor } nodes { name } }
+						}
+						... on PullRequest {
+							id title body url number state
+							repository { nameWithOwner }
+							assignees(first: 10) { nodes { login } }
+							labels(first: 100) { pageInfo { hasNextPage endCursor } nodes { name } }
+						}
+					}
+					fieldValues(first: 50) {
+						nodes {
+							__typename
+							... on ProjectV2ItemFieldSingleSelectValue {
+								name optionId
+								field { ... on ProjectV2FieldCommon { name } }
+							}
+							... on ProjectV2ItemFieldDateValue {
+								date
+								field { ... on ProjectV2FieldCommon { name } }
+							}
+							... on ProjectV2ItemFieldIterationValue {
+								title iterationId
+								field { ... on ProjectV2FieldCommon { name } }
+							}
+							... on ProjectV2ItemFieldNumberValue {
+								number
+								field { ... on ProjectV2FieldCommon { name } }
+							}
+							... on ProjectV2ItemFieldTextValue {
+								text
+								field { ... on ProjectV2FieldCommon { name } }
+							}
+							... on ProjectV2ItemFieldUserValue {
+								users(first: 10) { nodes { login } }
+								field { ... on ProjectV2FieldCommon { name } }
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
+// githubLogins is a GraphQL connection of users
+type githubLogins struct {
+	Nodes []struct {
+		Login string `json:"login"`
+	} `json:"nodes"`
+}
+
+// logins returns the user logins in the connection
+func (l githubLogins) logins() []string {
+	var out []string
+	for _, n := range l.Nodes {
+		out = append(out, n.Login)
+	}
+	return out
+}
+
+// githubItemNode mirrors a project item in githubItemsQuery's response
+type githubItemNode struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Content   *struct {
+		ID         string `json:"id"`
+		Title      string `json:"title"`
+		Body       string `json:"body"`
+		URL        string `json:"url"`
+		Number     int    `json:"number"`
+		State      string `json:"state"`
+		Repository struct {
+			NameWithOwner string `json:"nameWithOwner"`
+		} `json:"repository"`
+		Assignees githubLogins          `json:"assignees"`
+		Labels    githubLabelConnection `json:"labels"`
+	} `json:"content"`
+	FieldValues struct {
+		Nodes []struct {
+			Typename    string       `json:"__typename"`
+			Name        string       `json:"name"`
+			OptionID    string       `json:"optionId"`
+			Date        string       `json:"date"`
+			Title       string       `json:"title"`
+			IterationID string       `json:"iterationId"`
+			Number      float64      `json:"number"`
+			Text        string       `json:"text"`
+			Users       githubLogins `json:"users"`
+			Field       struct {
+				Name string `json:"name"`
+			} `json:"field"`
+		} `json:"nodes"`
+	} `json:"fieldValues"`
+}
+
+// toItem converts the raw response node to a GitHubProjectItem
+func (n githubItemNode) toItem() GitHubProjectItem {
+	item := GitHubProjectItem{
+		ID:          n.ID,
+		Type:        githubItemType(n.Type),
+		FieldValues: map[string]GitHubFieldValue{},
+		CreatedAt:   n.CreatedAt,
+		UpdatedAt:   n.UpdatedAt,
+	}
+
+	if c := n.Content; c != nil {
+		item.Content = GitHubItemContent{
+			ID:         c.ID,
+			Title:      c.Title,
+			Body:       c.Body,
+			URL:        c.URL,
+			Number:     c.Number,
+			State:      c.State,
+			Repository: c.Repository.NameWithOwner,
+			Assignees:  c.Assignees.logins(),
+		}
+		for _, label := range c.Labels.Nodes {
+			item.Content.Labels = append(item.Content.Labels, label.Name)
+		}
+	}
+
+	for _, fv := range n.FieldValues.Nodes {
+		if fv.Field.Name == "" {
+			continue // Built-in values without a field (e.g. repository, labels)
+		}
+
+		var value GitHubFieldValue
+		switch fv.Typename {
+		case "ProjectV2ItemFieldSingleSelectValue":
+			value = GitHubFieldValue{Type: "single_select", Text: fv.Name, OptionID: fv.OptionID}
+		case "ProjectV2ItemFieldDateValue":
+			value = GitHubFieldValue{Type: "date", Text: fv.Date}
+		case "ProjectV2ItemFieldIterationValue":
+			value = GitHubFieldValue{Type: "iteration", Text: fv.Title, OptionID: fv.IterationID}
+		case "ProjectV2ItemFieldNumberValue":
+			value = GitHubFieldValue{Type: "number", Number: fv.Number}
+		case "ProjectV2ItemFieldTextValue":
+			value = GitHubFieldValue{Type: "text", Text: fv.Text}
+		case "ProjectV2ItemFieldUserValue":
+			value = GitHubFieldValue{Type: "users", Users: fv.Users.logins()}
+		default:
+			continue
+		}
+		item.FieldValues[fv.Field.Name] = value
+	}
+
+	return item
+}
+
+// getProjectItems fetches all items in the project, following pagination cursors
+func (g *GitHubBackend) getProjectItems(projectID string) ([]GitHubProjectItem, error) {
+	items := []GitHubProjectItem{}
+	cursor := ""
+
+	for {
+		variables := map[string]interface{}{
+			"projectId": projectID,
+			"first":     githubItemsPageSize,
+		}
+		if cursor != "" {
+			variables["cursor"] = cursor
+		}
+
+		var result struct {
+			Node *struct {
+				Items struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []githubItemNode `json:"nodes"`
+				} `json:"items"`
+			} `json:"node"`
+		}
+		if err := g.gql.Do(githubItemsQuery, variables, &result); err != nil {
+			return nil, err
+		}
+		if result.Node == nil {
+			return nil, fmt.Errorf("project not found: %s", projectID)
+		}
+
+		page := result.Node.Items
+		for _, node := range page.Nodes {
+			if node.ID == "" {
+				continue
+			}
+			item := node.toItem()
+			if c := node.Content; c != nil && c.Labels.PageInfo.HasNextPage {
+				// Rarely more labels than fit in the page; fetch them all rather than drop some
+				labels, err := contentLabels(g.gql, githubContentLabelsQuery, c.ID)
+				if err != nil {
+					return nil, err
+				}
+				item.Content.Labels = nil
+				for _, label := range labels {
+					item.Content.Labels = append(item.Content.Labels, label.Name)
+				}
+			}
+			items = append(items, item)
+		}
+
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			break
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+
+	return items, nil
+}
+
+// itemToCard converts a GitHub project item to our Card format
+func (g *GitHubBackend) itemToCard(item GitHubProjectItem) *Card {
+	card := &Card{
+		ID:          item.ID,
+		Title:       item.Content.Title,
+		Description: item.Content.Body,
+		URL:         item.Content.URL, // Issues/PRs linked to the item
+		Tags:        item.Content.Labels,
+		ContentType: item.Type,
+		ContentID:   item.Content.ID,
+		CreatedAt:   item.CreatedAt,
+		ModifiedAt:  item.UpdatedAt,
+	}
+	if card.CreatedAt.IsZero() {
+		card.CreatedAt = time.Now()
+	}
+	if card.ModifiedAt.IsZero() {
+		card.ModifiedAt = card.CreatedAt
+	}
+
+	// Map Status field to our Column
+	card.Column = "BACKLOG" // Default column
+	if status, ok := item.FieldValues["Status"]; ok && status.Text != "" {
+		card.Column = g.mapStatusToColumn(status.Text)
+	}
+
+	// Assignees come from the project's Assignees field, falling back to the content's
+	assignees := item.Content.Assignees
+	if field, ok := item.FieldValues["Assignees"]; ok && len(field.Users) > 0 {
+		assignees = field.Users
+	}
+	if len(assignees) > 0 {
+		card.Assignee = "@" + strings.Join(assignees, ", @")
+	}
+
+	// Use the first date field that looks like a due date
+	for _, name := range []string{"Due Date", "Due", "Target Date", "End Date"} {
+		if field, ok := item.FieldValues[name]; ok && field.Type == "date" && field.Text != "" {
+			card.DueDate = field.Text
+			break
+		}
+	}
+
+	return card
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// boardKey identifies the project in the outbox journal
+func (g *GitHubBackend) boardKey() string {
+	key := "github:" + g.host + "/" + g.owner + "/"
+	if g.repoName != "" {
+		key += g.repoName + "/"
+	}
+	return key + strconv.Itoa(g.projectNum)
+}
+
+// journal runs send, or records entry in the outbox when GitHub is unreachable
+// Once anything is queued, later mutations queue behind it so they replay in order
+// A create is only queued if the request never left the machine, since GitHub may
+// have applied it before a timeout or gateway error
+func (g *GitHubBackend) journal(entry OutboxEntry, send func() error) error {
+	if g.outbox == nil {
+		return send()
+	}
+
+	entry.Board = g.boardKey()
+	if len(g.outbox.Pending(entry.Board)) > 0 {
+		return g.outbox.Append(entry)
+	}
+	err := send()
+	if isOfflineError(err) && (entry.Op != OutboxCreate || isUnsentError(err)) {
+		return g.outbox.Append(entry)
+	}
+	return err
+}
+
+// MoveCard moves a card to a different column in GitHub
+func (g *GitHubBackend) MoveCard(cardID string, toColumn string) error {
+	entry := OutboxEntry{Op: OutboxMove, CardID: cardID, Column: toColumn}
+	return g.journal(entry, func() error { return g.moveCard(cardID, toColumn) })
+}
+
+// UpdateCard updates a card's details in GitHub
+// Draft issues get title, body and assignees; issues and PRs also get labels from Tags
+func (g *GitHubBackend) UpdateCard(card *Card) error {
+	snapshot := *card
+	entry := OutboxEntry{Op: OutboxUpdate, CardID: card.ID, Card: &snapshot}
+	return g.journal(entry, func() error { return g.updateCard(card) })
+}
+
+// CreateCard creates a new draft issue in the project
+// With createAsIssues set, it creates an issue in the repository instead
+// While offline the card gets a local ID until the outbox is replayed
+func (g *GitHubBackend) CreateCard(title, description, column string) (*Card, error) {
+	var created *Card
+	var createErr error
+	now := time.Now()
+	local := &Card{
+		ID:          fmt.Sprintf("%s%d", outboxLocalIDPrefix, now.UnixNano()),
+		Title:       title,
+		Description: description,
+		Column:      column,
+		CreatedAt:   now,
+		ModifiedAt:  now,
+	}
+
+	entry := OutboxEntry{Op: OutboxCreate, CardID: local.ID, Column: column, Card: local}
+	err := g.journal(entry, func() error {
+		created, createErr = g.createCard(title, description, column)
+		if created == nil {
+			return createErr
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return local, nil // Queued in the outbox
+	}
+	return created, createErr
+}
+
+// CachedBoard returns the board as last fetched from GitHub, with queued offline
+// changes applied, and when it was fetched
+func (g *GitHubBackend) CachedBoard() (*Board, time.Time, error) {
+	board, synced, err := LoadBoardCache(g.boardKey())
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if g.outbox != nil {
+		ApplyOutbox(board, g.outbox.Pending(g.boardKey()))
+	}
+	return board, synced, nil
+}
+
+// PendingCards returns the IDs of cards with changes waiting in the outbox
+func (g *GitHubBackend) PendingCards() map[string]bool {
+	if g.outbox == nil {
+		return map[string]bool{}
+	}
+	return g.outbox.PendingCards(g.boardKey())
+}
+
+// ReplayOutbox sends the project's queued mutations to GitHub in order
+func (g *GitHubBackend) ReplayOutbox() OutboxReplayResult {
+	if g.outbox == nil {
+		return OutboxReplayResult{}
+	}
+
+	return g.outbox.Replay(g.boardKey(), func(entry OutboxEntry) (*Card, error) {
+		if err := requireSynced(entry.CardID); err != nil && entry.Op != OutboxCreate {
+			return nil, err // The create it depended on was rejected
+		}
+
+		switch entry.Op {
+		case OutboxMove:
+			return nil, g.moveCard(entry.CardID, entry.Column)
+		case OutboxUpdate:
+			return nil, g.updateCard(entry.Card)
+		case OutboxCreate:
+			card, err := g.createCard(entry.Card.Title, entry.Card.Description, entry.Column)
+			if card != nil && err != nil {
+				// The card exists but its column wasn't set; retry the move on the next replay
+				err = g.outbox.Append(OutboxEntry{Board: g.boardKey(), Op: OutboxMove, CardID: card.ID, Column: entry.Column})
+			}
+			return card, err
+		}
+		return nil, fmt.Errorf("unknown outbox operation %q", entry.Op)
+	})
+}
+
+// requireSynced rejects operations on cards that exist only in the outbox
+func requireSynced(cardID string) error {
+	if strings.HasPrefix(cardID, outboxLocalIDPrefix) {
+		return fmt.Errorf("card %s has not been synced to GitHub yet", cardID)
+	}
+	return nil
+}
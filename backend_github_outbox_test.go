@@ -0,0 +1,169 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGitHubOutboxQueuesWhileOfflineAndReplays(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("repositoryOwner", projectResponse)
+	f.on(`field(name: "Status")`, statusFieldResponse)
+	f.on("addProjectV2DraftIssue", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"addProjectV2DraftIssue": map[string]interface{}{
+			"projectItem": map[string]interface{}{"id": "PVTI_new", "content": map[string]string{"id": "DI_new"}},
+		}}
+	})
+	f.on("updateProjectV2ItemFieldValue", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{}
+	})
+	f.on("updateProjectV2DraftIssue", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{}
+	})
+
+	outbox, err := OpenOutboxFile(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := f.backend("acme", 3, "")
+	g.outbox = outbox
+	online := g.gql
+	g.gql = NewHTTPGraphQLClient("http://127.0.0.1:1/graphql", "test-token") // Nothing listens here
+
+	// Offline: everything is journaled instead of failing
+	if err := g.MoveCard("PVTI_1", "DONE"); err != nil {
+		t.Fatalf("offline MoveCard: %v", err)
+	}
+	card, err := g.CreateCard("Written on the train", "", "TODO")
+	if err != nil || !strings.HasPrefix(card.ID, outboxLocalIDPrefix) {
+		t.Fatalf("offline CreateCard = %+v, %v; want a local card", card, err)
+	}
+	card.Title = "Written on the train (edited)"
+	card.ContentType = "DraftIssue"
+	if err := g.UpdateCard(card); err != nil {
+		t.Fatalf("offline UpdateCard: %v", err)
+	}
+	if err := g.DeleteCard(card.ID); err == nil {
+		t.Error("DeleteCard of an unsynced card should fail")
+	}
+
+	pending := g.PendingCards()
+	if !pending["PVTI_1"] || !pending[card.ID] || len(outbox.Pending(g.boardKey())) != 3 {
+		t.Fatalf("pending = %v, entries = %d", pending, len(outbox.Pending(g.boardKey())))
+	}
+
+	// Reopening the journal sees the same entries
+	reopened, err := OpenOutboxFile(outbox.path)
+	if err != nil || len(reopened.Pending(g.boardKey())) != 3 {
+		t.Fatalf("reopened outbox: %v, %d entries", err, len(reopened.Pending(g.boardKey())))
+	}
+
+	// A replay while still offline keeps everything
+	if result := g.ReplayOutbox(); !result.Offline || result.Sent != 0 {
+		t.Fatalf("offline replay = %+v", result)
+	}
+
+	// Back online: entries replay in order and the local ID is remapped
+	g.gql = online
+	result := g.ReplayOutbox()
+	if result.Offline || result.Sent != 3 || len(result.Failed) != 0 {
+		t.Fatalf("replay = %+v", result)
+	}
+	if created := result.Created[card.ID]; created == nil || created.ID != "PVTI_new" {
+		t.Errorf("created = %+v, want PVTI_new", result.Created)
+	}
+	update := f.last("updateProjectV2DraftIssue")
+	if update.Variables["draftIssueId"] != "DI_new" || update.Variables["title"] != "Written on the train (edited)" {
+		t.Errorf("replayed update variables = %v", update.Variables)
+	}
+	if len(g.PendingCards()) != 0 {
+		t.Errorf("pending after replay = %v", g.PendingCards())
+	}
+}
+
+func TestGitHubOutboxDoesNotQueueCreatesThatMayHaveBeenApplied(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("repositoryOwner", projectResponse)
+	f.on(`field(name: "Status")`, statusFieldResponse)
+	var created atomic.Int32
+	f.on("addProjectV2DraftIssue", func(map[string]interface{}) interface{} {
+		// GitHub applies the mutation, but the response arrives too late
+		created.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		return map[string]interface{}{"addProjectV2DraftIssue": map[string]interface{}{
+			"projectItem": map[string]interface{}{"id": "PVTI_new", "content": map[string]string{"id": "DI_new"}},
+		}}
+	})
+
+	outbox, err := OpenOutboxFile(filepath.Join(t.TempDir(), "outbox.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := f.backend("acme", 3, "")
+	g.outbox = outbox
+	if err := g.loadProjectSchema(); err != nil {
+		t.Fatal(err)
+	}
+	g.gql.(*HTTPGraphQLClient).http.Timeout = 50 * time.Millisecond
+
+	card, err := g.CreateCard("Maybe created", "", "TODO")
+	if err == nil {
+		t.Fatalf("CreateCard = %+v, want the timeout", card)
+	}
+	if !isOfflineError(err) || isUnsentError(err) {
+		t.Errorf("timeout %v: offline %v, unsent %v", err, isOfflineError(err), isUnsentError(err))
+	}
+	if pending := outbox.Pending(g.boardKey()); len(pending) != 0 {
+		t.Errorf("queued %v; a replay would create the card again", pending)
+	}
+	if n := created.Load(); n != 1 {
+		t.Errorf("created %d times", n)
+	}
+}
+
+func TestApplyOutboxOverlaysPendingChanges(t *testing.T) {
+	board := &Board{
+		Columns: []Column{{Name: "TODO"}, {Name: "DONE"}},
+		Cards:   []*Card{{ID: "1", Title: "Old", Column: "TODO"}},
+	}
+	board.PopulateColumnCards()
+
+	ApplyOutbox(board, []OutboxEntry{
+		{Op: OutboxMove, CardID: "1", Column: "DONE"},
+		{Op: OutboxUpdate, CardID: "1", Card: &Card{ID: "1", Title: "New", Column: "TODO"}},
+		{Op: OutboxCreate, CardID: "local-1", Card: &Card{ID: "local-1", Title: "Offline", Column: "TODO"}},
+	})
+
+	if card := board.FindCard("1"); card.Title != "New" || card.Column != "DONE" {
+		t.Errorf("card 1 = %+v", card)
+	}
+	if len(board.Columns[0].Cards) != 1 || board.Columns[0].Cards[0].ID != "local-1" {
+		t.Errorf("TODO column = %v", board.Columns[0].Cards)
+	}
+}
+
+func TestIsOfflineError(t *testing.T) {
+	offline := []error{
+		&GitHubAPIError{StatusCode: 502, Status: "502 Bad Gateway"},
+		errors.New("gh api graphql: error connecting to api.github.com"),
+	}
+	for _, err := range offline {
+		if !isOfflineError(err) {
+			t.Errorf("isOfflineError(%v) = false", err)
+		}
+	}
+	online := []error{
+		nil,
+		&GitHubAPIError{StatusCode: 401, Status: "401 Unauthorized"},
+		GraphQLError{Message: "Could not resolve to a node"},
+	}
+	for _, err := range online {
+		if isOfflineError(err) {
+			t.Errorf("isOfflineError(%v) = true", err)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for GitHubScheduler
+const (
+	githubMaxRetries     = 4
+	githubRetryBaseDelay = 1 * time.Second
+	githubRetryMaxDelay  = 30 * time.Second
+	githubRateLimitWait  = 60 * time.Second // Longest wait for a quota reset before giving up
+)
+
+// githubMoveCoalesceDelay is how long a queued move waits for a newer move of the same card
+const githubMoveCoalesceDelay = 400 * time.Millisecond
+
+// RateLimit is the API quota reported by the X-RateLimit-* response headers
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// String formats the quota for the status bar
+func (r RateLimit) String() string {
+	return fmt.Sprintf("GitHub quota: %d/%d", r.Remaining, r.Limit)
+}
+
+// RateLimitReporter is implemented by clients and backends that know the remaining API quota
+type RateLimitReporter interface {
+	// RateLimit returns the most recently reported quota, or false if none is known yet
+	RateLimit() (RateLimit, bool)
+}
+
+// GitHubAPIError is a non-200 HTTP response from the GitHub API
+type GitHubAPIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+	RetryAfter time.Duration // From the Retry-After header (0 if absent)
+}
+
+func (e *GitHubAPIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("GitHub API: %s (%s)", e.Message, e.Status)
+	}
+	return fmt.Sprintf("GitHub API: %s", e.Status)
+}
+
+// rateLimited reports whether the request was rejected by a primary or secondary rate limit
+// Rejected requests were not applied, so they are always safe to retry
+func (e *GitHubAPIError) rateLimited() bool {
+	if e.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return e.StatusCode == http.StatusForbidden &&
+		(e.RetryAfter > 0 || strings.Contains(strings.ToLower(e.Message), "rate limit"))
+}
+
+// rateLimitTracker records the quota from response headers
+type rateLimitTracker struct {
+	mu    sync.Mutex
+	rate  RateLimit
+	known bool
+}
+
+// record updates the quota from a response's headers, if present
+func (t *rateLimitTracker) record(header http.Header) {
+	rate, ok := parseRateLimit(header)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rate = rate
+	t.known = true
+}
+
+// RateLimit returns the most recently recorded quota
+func (t *rateLimitTracker) RateLimit() (RateLimit, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rate, t.known
+}
+
+// parseRateLimit reads the X-RateLimit-Limit, -Remaining and -Reset headers
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	rate := RateLimit{Limit: limit, Remaining: remaining}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rate.Reset = time.Unix(reset, 0)
+	}
+	return rate, true
+}
+
+// parseRetryAfter reads the Retry-After header (seconds or an HTTP date)
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// apiErrorFromResponse builds a GitHubAPIError from a non-200 response
+func apiErrorFromResponse(statusCode int, status string, header http.Header, message string) *GitHubAPIError {
+	if status == "" {
+		status = fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode))
+	}
+	return &GitHubAPIError{
+		StatusCode: statusCode,
+		Status:     status,
+		Message:    message,
+		RetryAfter: parseRetryAfter(header, time.Now()),
+	}
+}
+
+// GitHubScheduler runs GraphQL requests one at a time, retrying transient failures
+// Rate-limited requests are retried after Retry-After or the quota reset; server
+// errors and network failures are retried with exponential backoff, but only for
+// requests that are safe to repeat
+type GitHubScheduler struct {
+	client     GraphQLClient
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	maxWait    time.Duration
+	sleep      func(time.Duration)
+	now        func() time.Time
+
+	mu sync.Mutex // Serializes requests so bursts don't trip secondary rate limits
+}
+
+// NewGitHubScheduler wraps client with retry and rate-limit handling
+func NewGitHubScheduler(client GraphQLClient) *GitHubScheduler {
+	return &GitHubScheduler{
+		client:     client,
+		maxRetries: githubMaxRetries,
+		baseDelay:  githubRetryBaseDelay,
+		maxDelay:   githubRetryMaxDelay,
+		maxWait:    githubRateLimitWait,
+		sleep:      time.Sleep,
+		now:        time.Now,
+	}
+}
+
+// Do runs a query or mutation, retrying it when GitHub reports a transient failure
+// Waits between attempts don't hold up other callers; only the requests are serialized
+func (s *GitHubScheduler) Do(query string, variables map[string]interface{}, out interface{}) error {
+	// Don't spend a request we know will be rejected
+	if rate, ok := s.RateLimit(); ok && rate.Remaining == 0 && rate.Reset.After(s.now()) {
+		wait := rate.Reset.Sub(s.now())
+		if wait > s.maxWait {
+			return fmt.Errorf("GitHub rate limit exceeded; resets at %s", rate.Reset.Format("15:04:05"))
+		}
+		s.sleep(wait)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := s.send(query, variables, out)
+		if err == nil {
+			return nil
+		}
+		if attempt >= s.maxRetries {
+			return err
+		}
+		delay, ok := s.retryDelay(err, query, attempt)
+		if !ok {
+			return err
+		}
+		s.sleep(delay)
+	}
+}
+
+// send runs one request, waiting for any request already in flight
+func (s *GitHubScheduler) send(query string, variables map[string]interface{}, out interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.Do(query, variables, out)
+}
+
+// RateLimit returns the quota last reported to the wrapped client
+func (s *GitHubScheduler) RateLimit() (RateLimit, bool) {
+	if reporter, ok := s.client.(RateLimitReporter); ok {
+		return reporter.RateLimit()
+	}
+	return RateLimit{}, false
+}
+
+// retryDelay decides whether err is worth retrying and how long to wait first
+func (s *GitHubScheduler) retryDelay(err error, query string, attempt int) (time.Duration, bool) {
+	backoff := s.baseDelay << attempt
+	if backoff > s.maxDelay {
+		backoff = s.maxDelay
+	}
+
+	var apiErr *GitHubAPIError
+	if errors.As(err, &apiErr) {
+		if apiErr.rateLimited() {
+			return s.rateLimitDelay(apiErr.RetryAfter, backoff)
+		}
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return backoff, githubIdempotent(query)
+		}
+		return 0, false
+	}
+
+	var gqlErr GraphQLError
+	if errors.As(err, &gqlErr) && gqlErr.Type == "RATE_LIMITED" {
+		return s.rateLimitDelay(0, backoff)
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return backoff, githubIdempotent(query)
+	}
+	return 0, false
+}
+
+// rateLimitDelay returns how long to wait after a rate-limited request
+// Secondary limits send Retry-After; primary limits wait for the quota reset
+func (s *GitHubScheduler) rateLimitDelay(retryAfter, backoff time.Duration) (time.Duration, bool) {
+	wait := retryAfter
+	if wait == 0 {
+		if rate, ok := s.RateLimit(); ok && rate.Remaining == 0 && rate.Reset.After(s.now()) {
+			wait = rate.Reset.Sub(s.now())
+		} else {
+			wait = backoff
+		}
+	}
+	if wait > s.maxWait {
+		return 0, false
+	}
+	return wait, true
+}
+
+// githubNonIdempotentMutations create something each time they run, so a request
+// that may have been applied before a server error must not be repeated
+var githubNonIdempotentMutations = []string{
+	"addProjectV2DraftIssue",
+	"addProjectV2ItemById",
+	"createIssue",
+	"convertProjectV2DraftIssueItemToIssue",
+}
+
+// githubIdempotent reports whether query can safely be sent again
+func githubIdempotent(query string) bool {
+	if !strings.HasPrefix(strings.TrimSpace(query), "mutation") {
+		return true
+	}
+	for _, name := range githubNonIdempotentMutations {
+		if strings.Contains(query, name+"(") {
+			return false
+		}
+	}
+	return true
+}
+
+// QueueMove records a move and returns a function that sends it after
+// githubMoveCoalesceDelay, unless a later move of the same card replaced it
+// Dragging a card across several columns in quick succession sends one mutation
+func (g *GitHubBackend) QueueMove(cardID, toColumn string) func() error {
+	g.movesMu.Lock()
+	if g.pendingMoves == nil {
+		g.pendingMoves = map[string]int{}
+	}
+	g.moveSeq++
+	seq := g.moveSeq
+	g.pendingMoves[cardID] = seq
+	g.movesMu.Unlock()
+
+	return func() error {
+		time.Sleep(githubMoveCoalesceDelay)
+
+		g.movesMu.Lock()
+		if g.pendingMoves[cardID] != seq {
+			g.movesMu.Unlock()
+			return nil // Superseded by a later move
+		}
+		delete(g.pendingMoves, cardID)
+		g.movesMu.Unlock()
+
+		return g.MoveCard(cardID, toColumn)
+	}
+}
+
+// RateLimit returns the remaining API quota, if the client has reported it
+func (g *GitHubBackend) RateLimit() (RateLimit, bool) {
+	if reporter, ok := g.gql.(RateLimitReporter); ok {
+		return reporter.RateLimit()
+	}
+	return RateLimit{}, false
+}
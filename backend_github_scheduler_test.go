@@ -0,0 +1,205 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// schedulerFor wraps the fake server's client in a scheduler that records sleeps instead of waiting
+func schedulerFor(f *fakeGitHub, sleeps *[]time.Duration) *GitHubScheduler {
+	s := NewGitHubScheduler(NewHTTPGraphQLClient(f.server.URL, "test-token"))
+	s.sleep = func(d time.Duration) { *sleeps = append(*sleeps, d) }
+	return s
+}
+
+func TestGitHubSchedulerRetriesServerErrors(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("viewer", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"viewer": map[string]string{"login": "octocat"}}
+	})
+	f.failures = []fakeFailure{{status: http.StatusBadGateway}, {status: http.StatusBadGateway}}
+
+	var sleeps []time.Duration
+	s := schedulerFor(f, &sleeps)
+	if err := s.Do(`query { viewer { login } }`, nil, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if len(f.sent()) != 3 {
+		t.Errorf("requests = %d, want 3", len(f.sent()))
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second}; !slices.Equal(sleeps, want) {
+		t.Errorf("backoff = %v, want %v", sleeps, want)
+	}
+
+	rate, ok := s.RateLimit()
+	if !ok || rate.Remaining != 4990 || rate.Limit != 5000 {
+		t.Errorf("RateLimit() = %+v, %v", rate, ok)
+	}
+	if got := rate.String(); got != "GitHub quota: 4990/5000" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestGitHubSchedulerDoesNotBlockWhileWaiting(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("viewer", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"viewer": map[string]string{"login": "octocat"}}
+	})
+	f.failures = []fakeFailure{{status: http.StatusBadGateway}}
+
+	// The first request backs off until a second one has gone through
+	s := NewGitHubScheduler(NewHTTPGraphQLClient(f.server.URL, "test-token"))
+	waiting, done := make(chan struct{}), make(chan struct{})
+	s.sleep = func(time.Duration) {
+		close(waiting)
+		<-done
+	}
+	first := make(chan error)
+	go func() { first <- s.Do(`query { viewer { login } }`, nil, nil) }()
+
+	<-waiting
+	if err := s.Do(`query { viewer { login } }`, nil, nil); err != nil {
+		t.Fatalf("second Do: %v", err)
+	}
+	close(done)
+	if err := <-first; err != nil {
+		t.Fatalf("first Do: %v", err)
+	}
+}
+
+func TestGitHubSchedulerDoesNotRepeatCreates(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.failures = []fakeFailure{{status: http.StatusBadGateway}}
+
+	var sleeps []time.Duration
+	s := schedulerFor(f, &sleeps)
+	err := s.Do(githubAddDraftIssueMutation, map[string]interface{}{"projectId": "PVT_1", "title": "x"}, nil)
+
+	var apiErr *GitHubAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("err = %v, want 502 GitHubAPIError", err)
+	}
+	if len(f.sent()) != 1 {
+		t.Errorf("requests = %d, want 1 (create must not be retried)", len(f.sent()))
+	}
+}
+
+func TestGitHubSchedulerHonorsRetryAfter(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("updateProjectV2ItemFieldValue", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{}
+	})
+	f.failures = []fakeFailure{{
+		status: http.StatusForbidden,
+		header: map[string]string{"Retry-After": "7"},
+	}}
+
+	var sleeps []time.Duration
+	s := schedulerFor(f, &sleeps)
+	if err := s.Do(githubUpdateStatusMutation, nil, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if want := []time.Duration{7 * time.Second}; !slices.Equal(sleeps, want) {
+		t.Errorf("sleeps = %v, want %v", sleeps, want)
+	}
+}
+
+func TestGitHubSchedulerWaitsForExhaustedQuota(t *testing.T) {
+	f := newFakeGitHub(t)
+	now := time.Unix(1700000000, 0)
+	f.failures = []fakeFailure{{
+		status: http.StatusForbidden,
+		header: map[string]string{
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     "1700003600",
+		},
+	}}
+
+	var sleeps []time.Duration
+	s := schedulerFor(f, &sleeps)
+	s.now = func() time.Time { return now }
+
+	// The reset is an hour away, longer than the scheduler is willing to wait
+	err := s.Do(`query { viewer { login } }`, nil, nil)
+	if err == nil || len(sleeps) != 0 {
+		t.Fatalf("err = %v, sleeps = %v; want immediate error", err, sleeps)
+	}
+	err = s.Do(`query { viewer { login } }`, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "rate limit exceeded") {
+		t.Errorf("second Do err = %v, want rate limit error without a request", err)
+	}
+	if len(f.sent()) != 1 {
+		t.Errorf("requests = %d, want 1", len(f.sent()))
+	}
+}
+
+func TestGraphQLErrorWithIndexPath(t *testing.T) {
+	response := `{"data": null, "errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded", "path": ["repository", "issues", 2]}]}`
+	err := decodeGraphQLResponse([]byte(response), nil)
+	if err == nil || err.Error() != "API rate limit exceeded" {
+		t.Fatalf("err = %v, want the GraphQL message", err)
+	}
+
+	var gqlErr GraphQLError
+	if !errors.As(err, &gqlErr) || len(gqlErr.Path) != 3 || gqlErr.Path[2] != float64(2) {
+		t.Errorf("GraphQL error = %+v", gqlErr)
+	}
+	s := NewGitHubScheduler(nil)
+	if delay, ok := s.retryDelay(err, `query { viewer { login } }`, 0); !ok || delay != s.baseDelay {
+		t.Errorf("retryDelay = %v, %v; want a rate-limit retry", delay, ok)
+	}
+}
+
+func TestGitHubQueueMoveCoalesces(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("repositoryOwner", projectResponse)
+	f.on("field(name", statusFieldResponse)
+	f.on("updateProjectV2ItemFieldValue", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{}
+	})
+	g := f.backend("acme", 1, "")
+
+	first := g.QueueMove("PVTI_1", "IN_PROGRESS")
+	second := g.QueueMove("PVTI_1", "DONE")
+	other := g.QueueMove("PVTI_2", "TODO")
+
+	for _, send := range []func() error{first, second, other} {
+		if err := send(); err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	}
+
+	var moves []string
+	for _, req := range f.sent() {
+		if strings.Contains(req.Query, "updateProjectV2ItemFieldValue") {
+			moves = append(moves, req.Variables["itemId"].(string)+"="+req.Variables["optionId"].(string))
+		}
+	}
+	if want := []string{"PVTI_1=O_done", "PVTI_2=O_todo"}; strings.Join(moves, ",") != strings.Join(want, ",") {
+		t.Errorf("moves = %v, want %v", moves, want)
+	}
+}
+
+func TestSplitGHIncludeOutput(t *testing.T) {
+	output := "HTTP/2.0 502 Bad Gateway\r\nX-Ratelimit-Limit: 5000\r\nX-Ratelimit-Remaining: 12\r\n\r\n{\"message\":\"Server Error\"}"
+	code, status, header, body := splitGHIncludeOutput([]byte(output))
+	if code != 502 || status != "502 Bad Gateway" {
+		t.Errorf("status = %d %q", code, status)
+	}
+	if rate, ok := parseRateLimit(header); !ok || rate.Remaining != 12 {
+		t.Errorf("rate limit = %+v, %v", rate, ok)
+	}
+	if string(body) != `{"message":"Server Error"}` {
+		t.Errorf("body = %q", body)
+	}
+
+	plain := []byte(`{"data":{}}`)
+	if code, _, _, body := splitGHIncludeOutput(plain); code != 0 || string(body) != string(plain) {
+		t.Errorf("plain output = %d %q", code, body)
+	}
+}
@@ -0,0 +1,570 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeGitHub is a local GraphQL server that answers queries by matching a substring of the query text
+type fakeGitHub struct {
+	t        *testing.T
+	server   *httptest.Server
+	routes   []fakeRoute
+	failures []fakeFailure // Returned, in order, before any route is consulted
+
+	mu       sync.Mutex // Guards requests, which the server's handler goroutines append to
+	requests []graphQLRequest
+}
+
+// fakeFailure is a non-200 response returned by the fake server
+type fakeFailure struct {
+	status int
+	header map[string]string
+}
+
+// fakeRoute answers queries containing match with the result of respond
+// A GraphQLError result is sent as the response's error instead of its data
+type fakeRoute struct {
+	match   string
+	respond func(vars map[string]interface{}) interface{}
+}
+
+// newFakeGitHub starts a fake GraphQL server that requires the token "test-token"
+func newFakeGitHub(t *testing.T) *fakeGitHub {
+	f := &fakeGitHub{t: t}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// on registers a response for queries containing match (first match wins)
+func (f *fakeGitHub) on(match string, respond func(vars map[string]interface{}) interface{}) {
+	f.routes = append(f.routes, fakeRoute{match: match, respond: respond})
+}
+
+// backend returns a GitHub backend talking to the fake server
+func (f *fakeGitHub) backend(owner string, projectNum int, repoName string) *GitHubBackend {
+	g := NewGitHubBackend("", owner, projectNum, repoName)
+	g.gql = NewHTTPGraphQLClient(f.server.URL, "test-token")
+	return g
+}
+
+// sent returns the requests received so far
+func (f *fakeGitHub) sent() []graphQLRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.requests)
+}
+
+// last returns the most recent request whose query contains match
+func (f *fakeGitHub) last(match string) graphQLRequest {
+	requests := f.sent()
+	for i := len(requests) - 1; i >= 0; i-- {
+		if strings.Contains(requests[i].Query, match) {
+			return requests[i]
+		}
+	}
+	f.t.Fatalf("no request matching %q", match)
+	return graphQLRequest{}
+}
+
+func (f *fakeGitHub) serve(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "bearer test-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Bad credentials"})
+		return
+	}
+
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		f.t.Errorf("invalid request body: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+
+	if len(f.failures) > 0 {
+		failure := f.failures[0]
+		f.failures = f.failures[1:]
+		for name, value := range failure.header {
+			w.Header().Set(name, value)
+		}
+		w.WriteHeader(failure.status)
+		json.NewEncoder(w).Encode(map[string]string{"message": http.StatusText(failure.status)})
+		return
+	}
+
+	w.Header().Set("X-RateLimit-Limit", "5000")
+	w.Header().Set("X-RateLimit-Remaining", "4990")
+	w.Header().Set("X-RateLimit-Reset", "1700000000")
+	for _, route := range f.routes {
+		if strings.Contains(req.Query, route.match) {
+			result := route.respond(req.Variables)
+			if gqlErr, ok := result.(GraphQLError); ok {
+				json.NewEncoder(w).Encode(map[string]interface{}{"errors": []GraphQLError{gqlErr}})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": result})
+			return
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]string{{"message": "unexpected query"}},
+	})
+}
+
+// projectResponse answers githubProjectQuery for an organization project
+func projectResponse(map[string]interface{}) interface{} {
+	return map[string]interface{}{
+		"repositoryOwner": map[string]interface{}{
+			"__typename": "Organization",
+			"projectV2": map[string]interface{}{
+				"id":               "PVT_1",
+				"title":            "Roadmap",
+				"shortDescription": "Q1 work",
+				"url":              "",
+				"repositories": map[string]interface{}{
+					"nodes": []interface{}{map[string]string{"nameWithOwner": "acme/api"}},
+				},
+			},
+		},
+	}
+}
+
+// statusFieldResponse answers githubStatusFieldQuery
+func statusFieldResponse(map[string]interface{}) interface{} {
+	return map[string]interface{}{
+		"node": map[string]interface{}{
+			"field": map[string]interface{}{
+				"id": "F_status",
+				"options": []interface{}{
+					map[string]string{"id": "O_todo", "name": "Todo"},
+					map[string]string{"id": "O_prog", "name": "In Progress"},
+					map[string]string{"id": "O_done", "name": "Done"},
+				},
+			},
+		},
+	}
+}
+
+func TestHTTPGraphQLClientSendsVariables(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("viewer", func(vars map[string]interface{}) interface{} {
+		return map[string]interface{}{"viewer": map[string]string{"login": vars["name"].(string)}}
+	})
+
+	client := NewHTTPGraphQLClient(f.server.URL, "test-token")
+	var out struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	tricky := "back\\slash \"quote\"\nnewline"
+	if err := client.Do(`query($name: String!) { viewer { login } }`, map[string]interface{}{"name": tricky}, &out); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	if out.Viewer.Login != tricky {
+		t.Errorf("round-tripped variable = %q, want %q", out.Viewer.Login, tricky)
+	}
+}
+
+func TestHTTPGraphQLClientErrors(t *testing.T) {
+	f := newFakeGitHub(t)
+
+	err := NewHTTPGraphQLClient(f.server.URL, "test-token").Do(`query { unknown }`, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "unexpected query") {
+		t.Errorf("GraphQL error = %v, want \"unexpected query\"", err)
+	}
+
+	err = NewHTTPGraphQLClient(f.server.URL, "wrong").Do(`query { viewer { login } }`, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Errorf("HTTP error = %v, want \"Bad credentials\"", err)
+	}
+}
+
+func TestGitHubLoadBoardPaginates(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("repositoryOwner", projectResponse)
+	f.on("items(first", func(vars map[string]interface{}) interface{} {
+		page := map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "c1"},
+			"nodes": []interface{}{map[string]interface{}{
+				"id": "PVTI_1", "type": "ISSUE",
+				"content": map[string]interface{}{
+					"id": "I_1", "title": "Fix login", "body": "401s", "url": "https://github.com/acme/api/issues/1",
+					"labels": map[string]interface{}{"nodes": []interface{}{map[string]string{"name": "bug"}}},
+				},
+				"fieldValues": map[string]interface{}{"nodes": []interface{}{
+					map[string]interface{}{"__typename": "ProjectV2ItemFieldSingleSelectValue", "name": "In Progress", "field": map[string]string{"name": "Status"}},
+					map[string]interface{}{"__typename": "ProjectV2ItemFieldDateValue", "date": "2025-03-01", "field": map[string]string{"name": "Target Date"}},
+					map[string]interface{}{"__typename": "ProjectV2ItemFieldUserValue", "field": map[string]string{"name": "Assignees"},
+						"users": map[string]interface{}{"nodes": []interface{}{map[string]string{"login": "alice"}, map[string]string{"login": "bob"}}}},
+				}},
+			}},
+		}
+		if vars["cursor"] == "c1" {
+			page = map[string]interface{}{
+				"pageInfo": map[string]interface{}{"hasNextPage": false, "endCursor": "c2"},
+				"nodes": []interface{}{map[string]interface{}{
+					"id": "PVTI_2", "type": "DRAFT_ISSUE",
+					"content":     map[string]interface{}{"id": "DI_2", "title": "Idea"},
+					"fieldValues": map[string]interface{}{"nodes": []interface{}{}},
+				}},
+			}
+		}
+		return map[string]interface{}{"node": map[string]interface{}{"items": page}}
+	})
+
+	board, err := f.backend("acme", 3, "").LoadBoard()
+	if err != nil {
+		t.Fatalf("LoadBoard: %v", err)
+	}
+
+	if board.URL != "https://github.com/orgs/acme/projects/3" {
+		t.Errorf("URL = %q, want org project URL", board.URL)
+	}
+	if len(board.Cards) != 2 {
+		t.Fatalf("got %d cards, want 2 (one per page)", len(board.Cards))
+	}
+
+	issue := board.FindCard("PVTI_1")
+	want := Card{
+		ID: "PVTI_1", Title: "Fix login", Description: "401s", URL: "https://github.com/acme/api/issues/1",
+		Tags: []string{"bug"}, Assignee: "@alice, @bob", DueDate: "2025-03-01", Column: "PROGRESS",
+		ContentType: "Issue", ContentID: "I_1",
+	}
+	got := *issue
+	got.CreatedAt, got.ModifiedAt = want.CreatedAt, want.ModifiedAt
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issue card = %+v, want %+v", got, want)
+	}
+
+	draft := board.FindCard("PVTI_2")
+	if draft.Column != "BACKLOG" || draft.ContentType != "DraftIssue" || draft.ContentID != "DI_2" {
+		t.Errorf("draft card = %+v", *draft)
+	}
+}
+
+func TestGitHubMoveCardUsesStatusOption(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("repositoryOwner", projectResponse)
+	f.on(`field(name: "Status")`, statusFieldResponse)
+	f.on("updateProjectV2ItemFieldValue", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"updateProjectV2ItemFieldValue": map[string]interface{}{"projectV2Item": map[string]string{"id": "PVTI_1"}}}
+	})
+
+	if err := f.backend("acme", 3, "").MoveCard("PVTI_1", "PROGRESS"); err != nil {
+		t.Fatalf("MoveCard: %v", err)
+	}
+
+	vars := f.last("updateProjectV2ItemFieldValue").Variables
+	want := map[string]interface{}{"projectId": "PVT_1", "itemId": "PVTI_1", "fieldId": "F_status", "optionId": "O_prog"}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("variables = %v, want %v", vars, want)
+	}
+}
+
+func TestGitHubUpdateCardDispatchesByContentType(t *testing.T) {
+	f := newFakeGitHub(t)
+	// The repository's labels come in two pages
+	f.on("repository { labels", func(vars map[string]interface{}) interface{} {
+		page := map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "c1"},
+			"nodes":    []interface{}{map[string]string{"id": "L_bug", "name": "bug"}},
+		}
+		if vars["cursor"] == "c1" {
+			page = map[string]interface{}{"nodes": []interface{}{map[string]string{"id": "L_p1", "name": "P1"}}}
+		}
+		return map[string]interface{}{"node": map[string]interface{}{"repository": map[string]interface{}{"labels": page}}}
+	})
+	// I_2 is already labeled bug and P1; the others have no labels
+	f.on("Issue { labels", func(vars map[string]interface{}) interface{} {
+		nodes := []interface{}{}
+		if vars["id"] == "I_2" {
+			nodes = []interface{}{map[string]string{"id": "L_bug", "name": "bug"}, map[string]string{"id": "L_p1", "name": "P1"}}
+		}
+		return map[string]interface{}{"node": map[string]interface{}{"labels": map[string]interface{}{"nodes": nodes}}}
+	})
+	f.on("user(login", func(vars map[string]interface{}) interface{} {
+		return map[string]interface{}{"user": map[string]string{"id": "U_" + vars["login"].(string)}}
+	})
+	f.on("updateIssue", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"updateIssue": map[string]interface{}{"issue": map[string]string{"id": "I_1"}}}
+	})
+	f.on("updatePullRequest", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"updatePullRequest": map[string]interface{}{"pullRequest": map[string]string{"id": "PR_1"}}}
+	})
+	f.on("updateProjectV2DraftIssue", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"updateProjectV2DraftIssue": map[string]interface{}{"draftIssue": map[string]string{"id": "DI_1"}}}
+	})
+
+	g := f.backend("acme", 3, "")
+
+	issue := &Card{ID: "PVTI_1", Title: "T", Description: "B", Tags: []string{"bug", "p1"}, Assignee: "@alice",
+		ContentType: "Issue", ContentID: "I_1"}
+	if err := g.UpdateCard(issue); err != nil {
+		t.Fatalf("UpdateCard(issue): %v", err)
+	}
+	vars := f.last("updateIssue").Variables
+	if !reflect.DeepEqual(vars["labelIds"], []interface{}{"L_bug", "L_p1"}) || !reflect.DeepEqual(vars["assigneeIds"], []interface{}{"U_alice"}) {
+		t.Errorf("updateIssue variables = %v", vars)
+	}
+
+	// Unchanged tags leave the labels alone, so labels the card doesn't show aren't dropped
+	labeled := &Card{ID: "PVTI_4", Title: "T", Tags: []string{"P1", "BUG"}, ContentType: "Issue", ContentID: "I_2"}
+	requests := len(f.sent())
+	if err := g.UpdateCard(labeled); err != nil {
+		t.Fatalf("UpdateCard(labeled): %v", err)
+	}
+	if vars := f.last("updateIssue").Variables; vars["id"] != "I_2" || vars["labelIds"] != nil {
+		t.Errorf("updateIssue variables = %v, want no labelIds", vars)
+	}
+	if len(f.sent()) != requests+2 {
+		t.Errorf("requests = %d, want the current labels and the update", len(f.sent())-requests)
+	}
+
+	pr := &Card{ID: "PVTI_2", Title: "T", ContentType: "PullRequest", ContentID: "PR_1"}
+	if err := g.UpdateCard(pr); err != nil {
+		t.Fatalf("UpdateCard(pr): %v", err)
+	}
+	if vars := f.last("updatePullRequest").Variables; vars["id"] != "PR_1" || vars["labelIds"] != nil {
+		t.Errorf("updatePullRequest variables = %v, want PR_1 without labelIds", vars)
+	}
+
+	draft := &Card{ID: "PVTI_3", Title: "T", Tags: []string{"ignored"}, ContentType: "DraftIssue", ContentID: "DI_1"}
+	if err := g.UpdateCard(draft); err != nil {
+		t.Fatalf("UpdateCard(draft): %v", err)
+	}
+	if id := f.last("updateProjectV2DraftIssue").Variables["draftIssueId"]; id != "DI_1" {
+		t.Errorf("updateProjectV2DraftIssue draftIssueId = %v, want DI_1", id)
+	}
+
+	unknown := &Card{ID: "PVTI_1", Tags: []string{"nope"}, ContentType: "Issue", ContentID: "I_1"}
+	if err := g.UpdateCard(unknown); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Errorf("UpdateCard with unknown label = %v, want error naming the label", err)
+	}
+}
+
+func TestGitHubCreateCardAsIssueUsesLinkedRepository(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("repositoryOwner", projectResponse)
+	f.on(`field(name: "Status")`, statusFieldResponse)
+	f.on("repository(owner", func(vars map[string]interface{}) interface{} {
+		return map[string]interface{}{"repository": map[string]string{"id": "R_" + vars["name"].(string)}}
+	})
+	f.on("createIssue", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"createIssue": map[string]interface{}{"issue": map[string]string{"id": "I_9", "url": "https://github.com/acme/api/issues/9"}}}
+	})
+	f.on("addProjectV2ItemById", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"addProjectV2ItemById": map[string]interface{}{"item": map[string]string{"id": "PVTI_9"}}}
+	})
+	f.on("updateProjectV2ItemFieldValue", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"updateProjectV2ItemFieldValue": map[string]interface{}{"projectV2Item": map[string]string{"id": "PVTI_9"}}}
+	})
+
+	g := f.backend("acme", 3, "")
+	g.createAsIssues = true
+	card, err := g.CreateCard("New", "Body", "TODO")
+	if err != nil {
+		t.Fatalf("CreateCard: %v", err)
+	}
+
+	if card.ID != "PVTI_9" || card.ContentType != "Issue" || card.URL != "https://github.com/acme/api/issues/9" {
+		t.Errorf("card = %+v", *card)
+	}
+	if repo := f.last("createIssue").Variables["repositoryId"]; repo != "R_api" {
+		t.Errorf("createIssue repositoryId = %v, want R_api (the linked repository)", repo)
+	}
+	if opt := f.last("updateProjectV2ItemFieldValue").Variables["optionId"]; opt != "O_todo" {
+		t.Errorf("initial column option = %v, want O_todo", opt)
+	}
+}
+
+func TestGitHubProjectInfoPaginatesLinkedRepositories(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("repositoryOwner", func(vars map[string]interface{}) interface{} {
+		response := projectResponse(vars)
+		project := response.(map[string]interface{})["repositoryOwner"].(map[string]interface{})["projectV2"].(map[string]interface{})
+		project["repositories"] = map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "r1"},
+			"nodes":    []interface{}{map[string]string{"nameWithOwner": "acme/api"}},
+		}
+		return response
+	})
+	f.on("... on ProjectV2 {", func(vars map[string]interface{}) interface{} {
+		if vars["id"] != "PVT_1" || vars["cursor"] != "r1" {
+			t.Errorf("repositories page variables = %v", vars)
+		}
+		return map[string]interface{}{"node": map[string]interface{}{"repositories": map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": false},
+			"nodes":    []interface{}{map[string]string{"nameWithOwner": "acme/web"}},
+		}}}
+	})
+
+	g := f.backend("acme", 3, "")
+	if _, err := g.getProjectInfo(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(g.linkedRepos, []string{"acme/api", "acme/web"}) {
+		t.Errorf("linked repositories = %v", g.linkedRepos)
+	}
+}
+
+func TestGHHostsToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.yml")
+	hosts := "github.com:\n    user: alice\n    oauth_token: gho_abc\n    git_protocol: https\nghe.example.com:\n    user: alice\n"
+	if err := os.WriteFile(path, []byte(hosts), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ghHostsToken(path, "github.com"); got != "gho_abc" {
+		t.Errorf("github.com token = %q, want gho_abc", got)
+	}
+	if got := ghHostsToken(path, "ghe.example.com"); got != "" {
+		t.Errorf("keyring-only host token = %q, want empty", got)
+	}
+	if got := ghHostsToken(filepath.Join(t.TempDir(), "missing.yml"), "github.com"); got != "" {
+		t.Errorf("missing file token = %q, want empty", got)
+	}
+}
+
+func TestGitHubTokenPrefersEnvironment(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "from-gh-token")
+	if got := githubToken("github.com"); got != "from-gh-token" {
+		t.Errorf("token = %q, want GH_TOKEN", got)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "from-github-token")
+	if got := githubToken("github.com"); got != "from-github-token" {
+		t.Errorf("token = %q, want GITHUB_TOKEN to take precedence", got)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	if _, ok := NewGraphQLClient("github.com").(*GHCLIGraphQLClient); !ok {
+		t.Errorf("without a token NewGraphQLClient should fall back to the gh CLI")
+	}
+}
+
+func TestParseAssignees(t *testing.T) {
+	got := parseAssignees("@alice, bob,@carol ")
+	want := []string{"alice", "bob", "carol"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseAssignees = %v, want %v", got, want)
+	}
+}
+
+func TestGitHubEnterpriseHost(t *testing.T) {
+	if got := githubGraphQLEndpoint("github.com"); got != "https://api.github.com/graphql" {
+		t.Errorf("github.com endpoint = %q", got)
+	}
+	if got := githubGraphQLEndpoint("ghe.example.com"); got != "https://ghe.example.com/api/graphql" {
+		t.Errorf("enterprise endpoint = %q", got)
+	}
+
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GITHUB_TOKEN", "dotcom-token")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "ghe-token")
+	if got := githubToken("ghe.example.com"); got != "ghe-token" {
+		t.Errorf("enterprise token = %q, want GH_ENTERPRISE_TOKEN", got)
+	}
+	client, ok := NewGraphQLClient("ghe.example.com").(*HTTPGraphQLClient)
+	if !ok || client.endpoint != "https://ghe.example.com/api/graphql" {
+		t.Errorf("enterprise client = %#v", client)
+	}
+
+	g := NewGitHubBackend("ghe.example.com", "acme", 3, "")
+	g.ownerType = "Organization"
+	if got := g.ProjectURL(); got != "https://ghe.example.com/orgs/acme/projects/3" {
+		t.Errorf("enterprise project URL = %q", got)
+	}
+}
+
+func TestResolveGitHubHost(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("GH_HOST", "")
+	if err := os.MkdirAll(filepath.Join(dir, "tkan"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "github_host: ghe.default.com\nboards:\n  acme/3:\n    github_host: https://ghe.acme.com/\n"
+	if err := os.WriteFile(filepath.Join(dir, "tkan", "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		flag, spec, ghHost, want string
+	}{
+		{"GHE.Flag.com", "acme/3", "", "ghe.flag.com"},
+		{"", "acme/3", "ghe.env.com", "ghe.acme.com"},
+		{"", "other/1", "ghe.env.com", "ghe.env.com"},
+		{"", "other/1", "", "ghe.default.com"},
+	}
+	for _, tt := range tests {
+		t.Setenv("GH_HOST", tt.ghHost)
+		if got := ResolveGitHubHost(tt.flag, tt.spec); got != tt.want {
+			t.Errorf("ResolveGitHubHost(%q, %q) with GH_HOST=%q = %q, want %q", tt.flag, tt.spec, tt.ghHost, got, tt.want)
+		}
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if got := ResolveGitHubHost("", "acme/3"); got != "github.com" {
+		t.Errorf("ResolveGitHubHost without config = %q, want github.com", got)
+	}
+}
+
+// fakeConverter is a local board whose cards can be converted to issues
+type fakeConverter struct {
+	*LocalBackend
+	converted []string
+}
+
+func (f *fakeConverter) ConvertToIssue(card *Card) error {
+	f.converted = append(f.converted, card.ID)
+	return nil
+}
+
+func TestConvertToIssueNeedsConfirmation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tkan.yaml")
+	if err := SaveBoard(path, sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+	backend := &fakeConverter{LocalBackend: NewLocalBackend(path)}
+	var model tea.Model = NewModelWithBackend(sqliteTestBoard(), nil, backend)
+	press := func(key tea.KeyMsg) {
+		var cmd tea.Cmd
+		model, cmd = model.(Model).handleKeyMsg(key)
+		if cmd != nil {
+			model, _ = model.Update(cmd())
+		}
+	}
+
+	// Typing I in the table filters instead of converting
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("I")})
+	press(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if len(backend.converted) != 0 || model.(Model).convertingCardID == "" {
+		t.Fatalf("converted %v before confirming", backend.converted)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	press(tea.KeyMsg{Type: tea.KeyCtrlG})
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if len(backend.converted) != 1 {
+		t.Errorf("converted %v, want one card", backend.converted)
+	}
+}
@@ -0,0 +1,414 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitLab board columns that aren't backed by a label
+const (
+	gitlabOpenColumn   = "Open"   // Open issues without a list label
+	gitlabClosedColumn = "Closed" // Closed issues
+)
+
+// gitlabPageSize is the number of issues fetched per request (the API maximum)
+const gitlabPageSize = 100
+
+// GitLabBackend implements Backend for GitLab issue boards
+// Each label list on the board is a column, between GitLab's Open and Closed lists;
+// cards are the project's issues, keyed by IID, and moving a card swaps its list label
+type GitLabBackend struct {
+	host    string // e.g. gitlab.example.com (or http://localhost:8080)
+	project string // Project path (group/subgroup/project)
+	boardID int
+	client  *restClient
+
+	// Delete issues when their cards are deleted, instead of closing them
+	deleteIssues bool
+
+	mu         sync.Mutex
+	listLabels []string       // Label of each board list, in board order (from the last LoadBoard)
+	userIDs    map[string]int // Username -> user ID for assignees
+}
+
+// NewGitLabBackend creates a backend for board boardID of host/project
+func NewGitLabBackend(host, project string, boardID int) *GitLabBackend {
+	return &GitLabBackend{
+		host:    host,
+		project: project,
+		boardID: boardID,
+		client:  newGitLabClient(gitlabBaseURL(host), gitlabToken(host)),
+		userIDs: map[string]int{},
+	}
+}
+
+// newGitLabClient creates a client for the API at baseURL (e.g. https://gitlab.example.com/api/v4)
+func newGitLabClient(baseURL, token string) *restClient {
+	return newRESTClient("GitLab", baseURL, "PRIVATE-TOKEN", token)
+}
+
+// ParseGitLabBoardSpec parses "host/group/project/board-id" (the project path may have subgroups)
+// The host may carry a scheme (http://localhost:8080/group/project/1); https is assumed otherwise
+func ParseGitLabBoardSpec(spec string) (host, project string, boardID int, err error) {
+	scheme := ""
+	if i := strings.Index(spec, "://"); i >= 0 {
+		scheme, spec = spec[:i+3], spec[i+3:]
+	}
+
+	parts := strings.Split(strings.Trim(spec, "/"), "/")
+	if len(parts) < 4 || slices.Contains(parts, "") {
+		return "", "", 0, fmt.Errorf("invalid GitLab board format. Use: host/group/project/board-id")
+	}
+
+	boardID, err = strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid board id: %s", parts[len(parts)-1])
+	}
+	return scheme + parts[0], strings.Join(parts[1:len(parts)-1], "/"), boardID, nil
+}
+
+// gitlabBaseURL returns the API root for a host ("gitlab.com" -> "https://gitlab.com/api/v4")
+func gitlabBaseURL(host string) string {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	return strings.TrimSuffix(host, "/") + "/api/v4"
+}
+
+// gitlabToken returns the access token for a GitLab host
+// GITLAB_TOKEN wins over gitlab_tokens in config.yaml
+func gitlabToken(host string) string {
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		return token
+	}
+	cfg, _ := LoadConfig()
+	name := host
+	if i := strings.Index(name, "://"); i >= 0 {
+		name = name[i+3:]
+	}
+	return cfg.GitLabTokens[name]
+}
+
+// projectPath returns an API path under the project
+func (g *GitLabBackend) projectPath(format string, args ...interface{}) string {
+	return "/projects/" + url.PathEscape(g.project) + fmt.Sprintf(format, args...)
+}
+
+// gitlabBoard is an issue board with its lists
+type gitlabBoard struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Lists []struct {
+		ID       int `json:"id"`
+		Position int `json:"position"`
+		Label    *struct {
+			Name string `json:"name"`
+		} `json:"label"` // Nil for assignee, milestone and iteration lists
+	} `json:"lists"`
+}
+
+// gitlabIssue is an issue as returned by the issues API
+type gitlabIssue struct {
+	IID         int      `json:"iid"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	State       string   `json:"state"` // opened or closed
+	WebURL      string   `json:"web_url"`
+	Labels      []string `json:"labels"`
+	Assignees   []struct {
+		Username string `json:"username"`
+	} `json:"assignees"`
+	DueDate   string    `json:"due_date"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// column returns the board column an issue is shown in
+func (i gitlabIssue) column(listLabels []string) string {
+	if i.State == "closed" {
+		return gitlabClosedColumn
+	}
+	for _, label := range listLabels {
+		if slices.Contains(i.Labels, label) {
+			return label
+		}
+	}
+	return gitlabOpenColumn
+}
+
+// toCard converts an issue to a card; list labels become the column rather than tags
+func (i gitlabIssue) toCard(listLabels []string) *Card {
+	card := &Card{
+		ID:          strconv.Itoa(i.IID),
+		Title:       i.Title,
+		Description: i.Description,
+		URL:         i.WebURL,
+		Column:      i.column(listLabels),
+		DueDate:     i.DueDate,
+		CreatedAt:   i.CreatedAt,
+		ModifiedAt:  i.UpdatedAt,
+		ContentType: "Issue",
+	}
+	for _, label := range i.Labels {
+		if !slices.Contains(listLabels, label) {
+			card.Tags = append(card.Tags, label)
+		}
+	}
+	var logins []string
+	for _, a := range i.Assignees {
+		logins = append(logins, a.Username)
+	}
+	if len(logins) > 0 {
+		card.Assignee = "@" + strings.Join(logins, ", @")
+	}
+	return card
+}
+
+// LoadBoard fetches the board's lists, the open issues and the most recently closed ones
+// Only the latest page of closed issues is loaded, so old projects stay fast
+func (g *GitLabBackend) LoadBoard() (*Board, error) {
+	listLabels, name, err := g.loadLists()
+	if err != nil {
+		return nil, err
+	}
+
+	board := &Board{
+		Name:       name,
+		URL:        strings.TrimSuffix(gitlabBaseURL(g.host), "/api/v4") + "/" + g.project + "/-/boards/" + strconv.Itoa(g.boardID),
+		Cards:      []*Card{},
+		CreatedAt:  time.Now(),
+		ModifiedAt: time.Now(),
+	}
+	board.Columns = append(board.Columns, Column{Name: gitlabOpenColumn})
+	for _, label := range listLabels {
+		board.Columns = append(board.Columns, Column{Name: label})
+	}
+	board.Columns = append(board.Columns, Column{Name: gitlabClosedColumn})
+
+	for page := "1"; page != ""; {
+		var issues []gitlabIssue
+		header, err := g.client.do(http.MethodGet, g.projectPath("/issues?state=opened&per_page=%d&page=%s", gitlabPageSize, page), nil, &issues)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issues: %w", err)
+		}
+		for _, issue := range issues {
+			board.Cards = append(board.Cards, issue.toCard(listLabels))
+		}
+		page = header.Get("X-Next-Page")
+	}
+
+	var closed []gitlabIssue
+	if _, err := g.client.do(http.MethodGet, g.projectPath("/issues?state=closed&order_by=updated_at&sort=desc&per_page=%d", gitlabPageSize), nil, &closed); err != nil {
+		return nil, fmt.Errorf("failed to get closed issues: %w", err)
+	}
+	for _, issue := range closed {
+		board.Cards = append(board.Cards, issue.toCard(listLabels))
+	}
+
+	board.PopulateColumnCards()
+	return board, nil
+}
+
+// loadLists fetches the board and returns the labels of its lists in board order
+func (g *GitLabBackend) loadLists() ([]string, string, error) {
+	var board gitlabBoard
+	if _, err := g.client.do(http.MethodGet, g.projectPath("/boards/%d", g.boardID), nil, &board); err != nil {
+		return nil, "", fmt.Errorf("failed to get board: %w", err)
+	}
+
+	sort.SliceStable(board.Lists, func(i, j int) bool { return board.Lists[i].Position < board.Lists[j].Position })
+	var labels []string
+	for _, list := range board.Lists {
+		if list.Label != nil {
+			labels = append(labels, list.Label.Name)
+		}
+	}
+
+	g.mu.Lock()
+	g.listLabels = labels
+	g.mu.Unlock()
+	return labels, board.Name, nil
+}
+
+// lists returns the board's list labels, loading them if needed
+func (g *GitLabBackend) lists() ([]string, error) {
+	g.mu.Lock()
+	labels := g.listLabels
+	g.mu.Unlock()
+	if labels != nil {
+		return labels, nil
+	}
+	labels, _, err := g.loadLists()
+	return labels, err
+}
+
+// resolveColumn returns the board's spelling of a column and whether it is a label list
+func (g *GitLabBackend) resolveColumn(column string) (name string, isList bool, err error) {
+	labels, err := g.lists()
+	if err != nil {
+		return "", false, err
+	}
+	for _, label := range labels {
+		if strings.EqualFold(label, column) {
+			return label, true, nil
+		}
+	}
+	switch {
+	case strings.EqualFold(column, gitlabOpenColumn):
+		return gitlabOpenColumn, false, nil
+	case strings.EqualFold(column, gitlabClosedColumn):
+		return gitlabClosedColumn, false, nil
+	}
+	return "", false, fmt.Errorf("board has no list %s", column)
+}
+
+// SaveBoard is a no-op for the GitLab backend (changes are immediate)
+func (g *GitLabBackend) SaveBoard(board *Board) error {
+	return nil
+}
+
+// MoveCard moves an issue between lists by swapping its list labels
+// Moving to Closed closes the issue; moving out of Closed reopens it
+func (g *GitLabBackend) MoveCard(cardID string, toColumn string) error {
+	column, isList, err := g.resolveColumn(toColumn)
+	if err != nil {
+		return err
+	}
+	labels, err := g.lists()
+	if err != nil {
+		return err
+	}
+
+	var issue gitlabIssue
+	if _, err := g.client.do(http.MethodGet, g.projectPath("/issues/%s", cardID), nil, &issue); err != nil {
+		return err
+	}
+
+	edit := map[string]interface{}{}
+	var remove []string
+	for _, label := range labels {
+		if label != column && slices.Contains(issue.Labels, label) {
+			remove = append(remove, label)
+		}
+	}
+	if len(remove) > 0 {
+		edit["remove_labels"] = strings.Join(remove, ",")
+	}
+	if isList {
+		edit["add_labels"] = column
+	}
+	switch {
+	case column == gitlabClosedColumn && issue.State != "closed":
+		edit["state_event"] = "close"
+	case column != gitlabClosedColumn && issue.State == "closed":
+		edit["state_event"] = "reopen"
+	}
+	if len(edit) == 0 {
+		return nil
+	}
+
+	_, err = g.client.do(http.MethodPut, g.projectPath("/issues/%s", cardID), edit, nil)
+	return err
+}
+
+// UpdateCard updates an issue's title, description, labels, assignees and due date
+// The labels are the card's tags plus the list label of its column
+func (g *GitLabBackend) UpdateCard(card *Card) error {
+	column, isList, err := g.resolveColumn(card.Column)
+	if err != nil {
+		return err
+	}
+	labels := slices.Clone(card.Tags)
+	if isList {
+		labels = append(labels, column)
+	}
+
+	assigneeIDs := []int{}
+	for _, login := range parseAssignees(card.Assignee) {
+		id, err := g.userID(login)
+		if err != nil {
+			return err
+		}
+		assigneeIDs = append(assigneeIDs, id)
+	}
+
+	_, err = g.client.do(http.MethodPut, g.projectPath("/issues/%s", card.ID), map[string]interface{}{
+		"title":        card.Title,
+		"description":  card.Description,
+		"labels":       strings.Join(labels, ","),
+		"assignee_ids": assigneeIDs,
+		"due_date":     card.DueDate,
+	}, nil)
+	return err
+}
+
+// userID looks up a user's ID by username
+func (g *GitLabBackend) userID(username string) (int, error) {
+	g.mu.Lock()
+	id, ok := g.userIDs[username]
+	g.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	var users []struct {
+		ID int `json:"id"`
+	}
+	if _, err := g.client.do(http.MethodGet, "/users?username="+url.QueryEscape(username), nil, &users); err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, fmt.Errorf("GitLab user not found: %s", username)
+	}
+
+	g.mu.Lock()
+	g.userIDs[username] = users[0].ID
+	g.mu.Unlock()
+	return users[0].ID, nil
+}
+
+// CreateCard opens an issue with the column's list label (closing it for the Closed column)
+func (g *GitLabBackend) CreateCard(title, description, column string) (*Card, error) {
+	columnName, isList, err := g.resolveColumn(column)
+	if err != nil {
+		return nil, err
+	}
+
+	create := map[string]interface{}{"title": title, "description": description}
+	if isList {
+		create["labels"] = columnName
+	}
+	var issue gitlabIssue
+	if _, err := g.client.do(http.MethodPost, g.projectPath("/issues"), create, &issue); err != nil {
+		return nil, err
+	}
+
+	if columnName == gitlabClosedColumn {
+		if _, err := g.client.do(http.MethodPut, g.projectPath("/issues/%d", issue.IID),
+			map[string]interface{}{"state_event": "close"}, &issue); err != nil {
+			return nil, fmt.Errorf("created issue #%d but failed to close it: %w", issue.IID, err)
+		}
+	}
+
+	labels, _ := g.lists()
+	return issue.toCard(labels), nil
+}
+
+// DeleteCard closes the issue and takes it off the board's lists; like the GitHub and
+// Gitea backends, it leaves the issue itself in place. With deleteIssues set the issue
+// is deleted instead (GitLab only allows this for project owners and admins).
+func (g *GitLabBackend) DeleteCard(cardID string) error {
+	if !g.deleteIssues {
+		return g.MoveCard(cardID, gitlabClosedColumn)
+	}
+	_, err := g.client.do(http.MethodDelete, g.projectPath("/issues/%s", cardID), nil, nil)
+	return err
+}
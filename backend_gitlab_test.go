@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitlabRequest is a request received by the fake GitLab server
+type gitlabRequest struct {
+	Method string
+	Path   string // Escaped path, without the query
+	Query  string
+	Body   map[string]interface{}
+}
+
+// fakeGitLab serves the recorded responses in testdata/gitlab for board 5 of acme/platform/api
+type fakeGitLab struct {
+	t        *testing.T
+	server   *httptest.Server
+	requests []gitlabRequest
+}
+
+// newFakeGitLab starts a fake server that requires the token "gitlab-token"
+func newFakeGitLab(t *testing.T) *fakeGitLab {
+	f := &fakeGitLab{t: t}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// backend returns a GitLab backend talking to the fake server
+func (f *fakeGitLab) backend() *GitLabBackend {
+	g := NewGitLabBackend(f.server.URL, "acme/platform/api", 5)
+	g.client = newGitLabClient(f.server.URL+"/api/v4", "gitlab-token")
+	return g
+}
+
+// fixture returns the contents of a recorded response
+func (f *fakeGitLab) fixture(name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", "gitlab", name))
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return data
+}
+
+// issue returns a recorded issue by IID
+func (f *fakeGitLab) issue(iid string) map[string]interface{} {
+	for _, name := range []string{"issues_opened_1.json", "issues_opened_2.json", "issues_closed.json"} {
+		var issues []map[string]interface{}
+		json.Unmarshal(f.fixture(name), &issues)
+		for _, issue := range issues {
+			if fmt.Sprint(issue["iid"]) == iid {
+				return issue
+			}
+		}
+	}
+	return nil
+}
+
+func (f *fakeGitLab) serve(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("PRIVATE-TOKEN") != "gitlab-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"401 Unauthorized"}`))
+		return
+	}
+
+	req := gitlabRequest{Method: r.Method, Path: r.URL.EscapedPath(), Query: r.URL.RawQuery}
+	json.NewDecoder(r.Body).Decode(&req.Body)
+	f.requests = append(f.requests, req)
+
+	const project = "/api/v4/projects/acme%2Fplatform%2Fapi"
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodGet && req.Path == project+"/boards/5":
+		w.Write(f.fixture("board.json"))
+	case r.Method == http.MethodGet && req.Path == project+"/issues" && query.Get("state") == "opened":
+		if query.Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			w.Write(f.fixture("issues_opened_1.json"))
+			return
+		}
+		w.Write(f.fixture("issues_opened_2.json"))
+	case r.Method == http.MethodGet && req.Path == project+"/issues" && query.Get("state") == "closed":
+		w.Write(f.fixture("issues_closed.json"))
+	case r.Method == http.MethodGet && strings.HasPrefix(req.Path, project+"/issues/"):
+		json.NewEncoder(w).Encode(f.issue(strings.TrimPrefix(req.Path, project+"/issues/")))
+	case r.Method == http.MethodGet && req.Path == "/api/v4/users":
+		if query.Get("username") == "bob" {
+			w.Write(f.fixture("users_bob.json"))
+			return
+		}
+		w.Write([]byte("[]"))
+	case r.Method == http.MethodPost && req.Path == project+"/issues":
+		labels := []string{}
+		if l, _ := req.Body["labels"].(string); l != "" {
+			labels = strings.Split(l, ",")
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"iid": 15, "title": req.Body["title"], "state": "opened", "labels": labels})
+	case r.Method == http.MethodPut && strings.HasPrefix(req.Path, project+"/issues/"):
+		issue := f.issue(strings.TrimPrefix(req.Path, project+"/issues/"))
+		if issue == nil {
+			issue = map[string]interface{}{"iid": 15, "state": "opened"}
+		}
+		if req.Body["state_event"] == "close" {
+			issue["state"] = "closed"
+		}
+		json.NewEncoder(w).Encode(issue)
+	case r.Method == http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"404 Not found"}`))
+	}
+}
+
+// last returns the most recent request with the given method
+func (f *fakeGitLab) last(method string) gitlabRequest {
+	for i := len(f.requests) - 1; i >= 0; i-- {
+		if f.requests[i].Method == method {
+			return f.requests[i]
+		}
+	}
+	f.t.Fatalf("no %s request", method)
+	return gitlabRequest{}
+}
+
+func TestParseGitLabBoardSpec(t *testing.T) {
+	host, project, id, err := ParseGitLabBoardSpec("gitlab.example.com/acme/platform/api/5")
+	if err != nil || host != "gitlab.example.com" || project != "acme/platform/api" || id != 5 {
+		t.Errorf("got %q %q %d %v", host, project, id, err)
+	}
+	if host, _, _, err := ParseGitLabBoardSpec("http://localhost:8080/acme/api/1"); err != nil || host != "http://localhost:8080" {
+		t.Errorf("host with scheme = %q, %v", host, err)
+	}
+	for _, bad := range []string{"gitlab.com/acme/1", "gitlab.com/acme/api/board"} {
+		if _, _, _, err := ParseGitLabBoardSpec(bad); err == nil {
+			t.Errorf("%q should be rejected", bad)
+		}
+	}
+}
+
+func TestGitLabLoadBoard(t *testing.T) {
+	f := newFakeGitLab(t)
+	board, err := f.backend().LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var columns []string
+	for _, col := range board.Columns {
+		columns = append(columns, col.Name)
+	}
+	if strings.Join(columns, ",") != "Open,Doing,Review,Closed" {
+		t.Errorf("columns = %v", columns)
+	}
+	if len(board.Cards) != 4 {
+		t.Fatalf("got %d cards, want 4 (both pages and the closed issue)", len(board.Cards))
+	}
+
+	want := map[string]string{"12": "Doing", "13": "Open", "14": "Review", "9": "Closed"}
+	for id, column := range want {
+		if card := board.FindCard(id); card == nil || card.Column != column {
+			t.Errorf("card %s = %+v, want column %s", id, card, column)
+		}
+	}
+	card := board.FindCard("12")
+	if strings.Join(card.Tags, ",") != "security" || card.Assignee != "@alice, @bob" || card.DueDate != "2025-02-01" ||
+		card.URL != "https://gitlab.example.com/acme/platform/api/-/issues/12" {
+		t.Errorf("card 12 = %+v", card)
+	}
+}
+
+func TestGitLabMoveCardSwapsListLabels(t *testing.T) {
+	f := newFakeGitLab(t)
+	g := f.backend()
+
+	if err := g.MoveCard("12", "review"); err != nil {
+		t.Fatal(err)
+	}
+	move := f.last(http.MethodPut)
+	if move.Path != "/api/v4/projects/acme%2Fplatform%2Fapi/issues/12" || move.Body["add_labels"] != "Review" ||
+		move.Body["remove_labels"] != "Doing" || move.Body["state_event"] != nil {
+		t.Errorf("move to Review = %+v", move)
+	}
+
+	if err := g.MoveCard("12", "Closed"); err != nil {
+		t.Fatal(err)
+	}
+	if move := f.last(http.MethodPut); move.Body["state_event"] != "close" || move.Body["add_labels"] != nil || move.Body["remove_labels"] != "Doing" {
+		t.Errorf("move to Closed = %+v", move)
+	}
+
+	if err := g.MoveCard("9", "Open"); err != nil {
+		t.Fatal(err)
+	}
+	if move := f.last(http.MethodPut); move.Body["state_event"] != "reopen" || move.Body["remove_labels"] != nil {
+		t.Errorf("move closed issue to Open = %+v", move)
+	}
+
+	if err := g.MoveCard("12", "Nowhere"); err == nil {
+		t.Error("moving to an unknown list should fail")
+	}
+}
+
+func TestGitLabCreateAndUpdateCard(t *testing.T) {
+	f := newFakeGitLab(t)
+	g := f.backend()
+
+	card, err := g.CreateCard("New issue", "Details", "doing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if create := f.last(http.MethodPost); create.Body["labels"] != "Doing" || create.Body["title"] != "New issue" {
+		t.Errorf("create = %+v", create)
+	}
+	if card.ID != "15" || card.Column != "Doing" || len(card.Tags) != 0 {
+		t.Errorf("created card = %+v", card)
+	}
+
+	card.Tags = []string{"backend"}
+	card.Assignee = "@bob"
+	card.DueDate = "2025-03-01"
+	if err := g.UpdateCard(card); err != nil {
+		t.Fatal(err)
+	}
+	update := f.last(http.MethodPut)
+	if update.Body["labels"] != "backend,Doing" || update.Body["due_date"] != "2025-03-01" {
+		t.Errorf("update = %+v", update)
+	}
+	if ids, _ := update.Body["assignee_ids"].([]interface{}); len(ids) != 1 || ids[0] != float64(7) {
+		t.Errorf("assignee_ids = %v", update.Body["assignee_ids"])
+	}
+
+	card.Assignee = "@nobody"
+	if err := g.UpdateCard(card); err == nil {
+		t.Error("an unknown assignee should fail")
+	}
+
+	// Deleting a card closes its issue unless deleting issues was asked for
+	if err := g.DeleteCard("12"); err != nil {
+		t.Fatal(err)
+	}
+	if del := f.last(http.MethodPut); del.Path != "/api/v4/projects/acme%2Fplatform%2Fapi/issues/12" ||
+		del.Body["state_event"] != "close" || del.Body["remove_labels"] != "Doing" {
+		t.Errorf("delete = %+v", del)
+	}
+	for _, req := range f.requests {
+		if req.Method == http.MethodDelete {
+			t.Errorf("issue deleted without opting in: %+v", req)
+		}
+	}
+
+	g.deleteIssues = true
+	if err := g.DeleteCard("15"); err != nil {
+		t.Fatal(err)
+	}
+	if del := f.last(http.MethodDelete); del.Path != "/api/v4/projects/acme%2Fplatform%2Fapi/issues/15" {
+		t.Errorf("delete = %+v", del)
+	}
+}
+
+func TestGitLabAPIError(t *testing.T) {
+	f := newFakeGitLab(t)
+	g := f.backend()
+	g.client = newGitLabClient(f.server.URL+"/api/v4", "wrong")
+
+	_, err := g.LoadBoard()
+	var apiErr *RESTAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Service != "GitLab" {
+		t.Errorf("err = %v", err)
+	}
+}
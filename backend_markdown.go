@@ -0,0 +1,632 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MarkdownBackend implements Backend for a Markdown task list such as TODO.md
+// Each "## " heading is a column and each "- [ ]" item under it a card (see exportMarkdown
+// for the item format). Saving only rewrites the items that changed, so prose, comments
+// and formatting elsewhere in the file are kept byte for byte.
+//
+// Items with an <!-- id:N --> comment keep that ID; the others are numbered in file order.
+// The parsed file is cached so numbers stay stable while cards are added and deleted,
+// and carried over by title when the file is edited elsewhere.
+type MarkdownBackend struct {
+	filePath string
+
+	mu      sync.Mutex
+	doc     *mdDocument // Last document read or written
+	modTime time.Time   // File modification time and size when doc was read or written
+	size    int64
+}
+
+// NewMarkdownBackend creates a backend for the Markdown task list at filePath
+func NewMarkdownBackend(filePath string) *MarkdownBackend {
+	return &MarkdownBackend{filePath: filePath}
+}
+
+// isMarkdownBoard reports whether a board path is a Markdown task list
+func isMarkdownBoard(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
+
+// defaultName returns the board name used when the file has no "# " title: its directory's name
+func (b *MarkdownBackend) defaultName() string {
+	dir, err := filepath.Abs(filepath.Dir(b.filePath))
+	if err != nil {
+		dir = filepath.Dir(b.filePath)
+	}
+	return filepath.Base(dir)
+}
+
+// load returns the parsed file, re-reading it only if it changed since the last read or write
+// Must be called with mu held
+func (b *MarkdownBackend) load() (*mdDocument, error) {
+	info, err := os.Stat(b.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read board file: %w", err)
+	}
+	if b.doc != nil && info.ModTime().Equal(b.modTime) && info.Size() == b.size {
+		return b.doc, nil
+	}
+
+	data, err := os.ReadFile(b.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read board file: %w", err)
+	}
+	doc := parseMarkdownDocument(string(data))
+	doc.assignIDs(b.doc)
+
+	b.doc, b.modTime, b.size = doc, info.ModTime(), info.Size()
+	return doc, nil
+}
+
+// LoadBoard reads the task list
+func (b *MarkdownBackend) LoadBoard() (*Board, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	doc, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	return doc.board(b.defaultName(), b.modTime), nil
+}
+
+// SaveBoard writes the board's cards back into the task list
+func (b *MarkdownBackend) SaveBoard(board *Board) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	doc, err := b.load()
+	if errors.Is(err, fs.ErrNotExist) {
+		doc = parseMarkdownDocument("") // A new task list
+	} else if err != nil {
+		return err
+	}
+	doc.apply(board, b.defaultName())
+
+	if err := os.WriteFile(b.filePath, []byte(doc.render()), 0644); err != nil {
+		b.doc = nil // The cached document no longer matches the file
+		return fmt.Errorf("failed to write board file: %w", err)
+	}
+	if info, err := os.Stat(b.filePath); err == nil {
+		b.modTime, b.size = info.ModTime(), info.Size()
+	} else {
+		b.doc = nil
+	}
+	return nil
+}
+
+// MoveCard moves a card to a different column
+func (b *MarkdownBackend) MoveCard(cardID string, toColumn string) error {
+	board, err := b.LoadBoard()
+	if err != nil {
+		return err
+	}
+	card := board.FindCard(cardID)
+	if card == nil {
+		return fmt.Errorf("card not found: %s", cardID)
+	}
+	card.Column = toColumn
+	return b.SaveBoard(board)
+}
+
+// UpdateCard updates a card's details
+func (b *MarkdownBackend) UpdateCard(card *Card) error {
+	board, err := b.LoadBoard()
+	if err != nil {
+		return err
+	}
+	for i, c := range board.Cards {
+		if c.ID == card.ID {
+			board.Cards[i] = card
+			return b.SaveBoard(board)
+		}
+	}
+	return fmt.Errorf("card not found: %s", card.ID)
+}
+
+// CreateCard adds an item at the end of a column's list
+func (b *MarkdownBackend) CreateCard(title, description, column string) (*Card, error) {
+	board, err := b.LoadBoard()
+	if err != nil {
+		return nil, err
+	}
+
+	card := &Card{
+		ID:          board.NextCardID(),
+		Title:       title,
+		Description: description,
+		Column:      column,
+		CreatedAt:   time.Now(),
+		ModifiedAt:  time.Now(),
+	}
+	board.Cards = append(board.Cards, card)
+
+	if err := b.SaveBoard(board); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// DeleteCard removes a card's item (and its description lines) from the file
+func (b *MarkdownBackend) DeleteCard(cardID string) error {
+	board, err := b.LoadBoard()
+	if err != nil {
+		return err
+	}
+	for i, card := range board.Cards {
+		if card.ID == cardID {
+			board.Cards = append(board.Cards[:i], board.Cards[i+1:]...)
+			break
+		}
+	}
+	return b.SaveBoard(board)
+}
+
+// mdDocument is a Markdown task list split into the parts tkan edits
+// Lines that aren't column headings or items are kept verbatim
+type mdDocument struct {
+	preamble []string     // Lines before the first column heading
+	sections []*mdSection // One per "## " heading
+	crlf     bool         // Lines end in \r\n
+	newline  bool         // The file ends with a newline
+}
+
+// mdSection is a column heading and the lines under it
+type mdSection struct {
+	heading string
+	name    string
+	nodes   []*mdNode
+}
+
+// mdNode is a checklist item (with its indented description lines) or a single other line
+type mdNode struct {
+	lines     []string
+	item      bool
+	id        string
+	idComment bool // The ID comes from an <!-- id:N --> comment in the item
+	card      Card // The item's fields as last read or written
+}
+
+// blank reports whether the node is an empty line
+func (n *mdNode) blank() bool {
+	return !n.item && strings.TrimSpace(n.lines[0]) == ""
+}
+
+// parseMarkdownDocument splits a task list into its preamble, sections and items
+// Headings and items inside fenced code blocks are left alone
+func parseMarkdownDocument(text string) *mdDocument {
+	doc := &mdDocument{crlf: strings.Contains(text, "\r\n")}
+	if doc.crlf {
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+	}
+	doc.newline = text == "" || strings.HasSuffix(text, "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return doc
+	}
+
+	var section *mdSection
+	var item *mdNode
+	fence := ""
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if item != nil && mdContinuation(line) {
+			item.lines = append(item.lines, line)
+			continue
+		}
+		// Blank lines between paragraphs of a description belong to the item
+		if item != nil && strings.TrimSpace(line) == "" {
+			next := i + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next < len(lines) && mdContinuation(lines[next]) {
+				item.lines = append(item.lines, lines[i:next]...)
+				i = next - 1
+				continue
+			}
+		}
+		item = nil
+
+		switch {
+		case fence != "":
+			if strings.HasPrefix(strings.TrimSpace(line), fence) {
+				fence = ""
+			}
+		case mdFence(line) != "":
+			fence = mdFence(line)
+		case strings.HasPrefix(line, "## "):
+			section = &mdSection{heading: line, name: strings.TrimSpace(line[3:])}
+			doc.sections = append(doc.sections, section)
+			continue
+		case section != nil && mdItemPattern.MatchString(line):
+			item = &mdNode{lines: []string{line}, item: true}
+			section.nodes = append(section.nodes, item)
+			continue
+		}
+
+		if section == nil {
+			doc.preamble = append(doc.preamble, line)
+		} else {
+			section.nodes = append(section.nodes, &mdNode{lines: []string{line}})
+		}
+	}
+
+	for _, s := range doc.sections {
+		for _, n := range s.nodes {
+			if n.item {
+				n.parse()
+			}
+		}
+	}
+	return doc
+}
+
+// mdContinuation reports whether a line following an item continues its description
+func mdContinuation(line string) bool {
+	return strings.TrimSpace(line) != "" && (strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t"))
+}
+
+// mdFence returns the fence marker if line opens a fenced code block
+func mdFence(line string) string {
+	trimmed := strings.TrimSpace(line)
+	for _, fence := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, fence) {
+			return fence
+		}
+	}
+	return ""
+}
+
+// parse reads the card fields from an item's lines
+func (n *mdNode) parse() {
+	m := mdItemPattern.FindStringSubmatch(n.lines[0])
+	card := parseMarkdownItem(m[2])
+	n.id, n.idComment = card.ID, card.ID != ""
+
+	var description []string
+	for _, line := range n.lines[1:] {
+		if strings.HasPrefix(line, "\t") {
+			description = append(description, line[1:])
+		} else {
+			description = append(description, strings.TrimPrefix(line, "  "))
+		}
+	}
+	card.Description = strings.Join(description, "\n")
+	n.card = *card
+}
+
+// items returns the document's items in file order
+func (d *mdDocument) items() []*mdNode {
+	var items []*mdNode
+	for _, s := range d.sections {
+		for _, n := range s.nodes {
+			if n.item {
+				items = append(items, n)
+			}
+		}
+	}
+	return items
+}
+
+// assignIDs gives every item without an ID comment an ID: the one an item with the
+// same title had in prev (the document as read before), else the next free number
+func (d *mdDocument) assignIDs(prev *mdDocument) {
+	items := d.items()
+	taken := map[string]bool{}
+	for _, n := range items {
+		if n.idComment {
+			taken[n.id] = true
+		}
+	}
+
+	byTitle := map[string][]string{}
+	if prev != nil {
+		for _, n := range prev.items() {
+			if !taken[n.id] {
+				byTitle[n.card.Title] = append(byTitle[n.card.Title], n.id)
+			}
+		}
+	}
+	for _, n := range items {
+		if n.idComment {
+			continue
+		}
+		for ids := byTitle[n.card.Title]; len(ids) > 0; ids = ids[1:] {
+			if !taken[ids[0]] {
+				n.id = ids[0]
+				taken[n.id] = true
+				byTitle[n.card.Title] = ids[1:]
+				break
+			}
+		}
+	}
+
+	next := 1
+	for _, n := range items {
+		if n.id != "" {
+			continue
+		}
+		for taken[strconv.Itoa(next)] {
+			next++
+		}
+		n.id = strconv.Itoa(next)
+		taken[n.id] = true
+	}
+}
+
+// board builds a board from the document; the file's modification time stands in
+// for the cards' timestamps, which the format doesn't store
+func (d *mdDocument) board(defaultName string, modTime time.Time) *Board {
+	board := &Board{
+		Name:       defaultName,
+		Cards:      []*Card{},
+		CreatedAt:  modTime,
+		ModifiedAt: modTime,
+	}
+	if i := d.titleLine(); i >= 0 {
+		board.Name = strings.TrimSpace(d.preamble[i][2:])
+	}
+
+	for _, s := range d.sections {
+		column, ok := board.ResolveColumn(s.name)
+		if !ok {
+			column = s.name
+			board.Columns = append(board.Columns, Column{Name: column})
+		}
+		for _, n := range s.nodes {
+			if !n.item {
+				continue
+			}
+			card := n.card
+			card.ID = n.id
+			card.Column = column
+			card.Tags = slices.Clone(card.Tags)
+			card.CreatedAt = modTime
+			card.ModifiedAt = modTime
+			board.Cards = append(board.Cards, &card)
+		}
+	}
+
+	board.PopulateColumnCards()
+	return board
+}
+
+// titleLine returns the index of the "# " title in the preamble, or -1
+func (d *mdDocument) titleLine() int {
+	for i, line := range d.preamble {
+		if strings.HasPrefix(line, "# ") {
+			return i
+		}
+	}
+	return -1
+}
+
+// apply updates the document to match board: changed items are re-rendered, deleted
+// ones removed, and moved and new ones added after the last item of their column
+func (d *mdDocument) apply(board *Board, defaultName string) {
+	if i := d.titleLine(); i >= 0 {
+		if strings.TrimSpace(d.preamble[i][2:]) != board.Name {
+			d.preamble[i] = "# " + board.Name
+		}
+	} else if board.Name != "" && board.Name != defaultName {
+		d.preamble = append([]string{"# " + board.Name, ""}, d.preamble...)
+	}
+
+	cards := map[string]*Card{}
+	for _, card := range board.Cards {
+		cards[card.ID] = card
+	}
+
+	inFile := map[string]bool{}
+	moved := map[string]*mdNode{}
+	for _, s := range d.sections {
+		nodes := s.nodes[:0]
+		collapse := false // A removed item left a blank line before the next one
+		for _, n := range s.nodes {
+			if collapse && n.blank() {
+				collapse = false
+				continue
+			}
+			collapse = false
+			if !n.item {
+				nodes = append(nodes, n)
+				continue
+			}
+
+			inFile[n.id] = true
+			card, ok := cards[n.id]
+			if ok && strings.EqualFold(card.Column, s.name) {
+				n.update(card, false)
+				nodes = append(nodes, n)
+				continue
+			}
+			if ok {
+				n.update(card, true)
+				moved[n.id] = n
+			}
+			collapse = len(nodes) > 0 && nodes[len(nodes)-1].blank()
+		}
+		s.nodes = nodes
+	}
+
+	for _, col := range board.Columns {
+		d.section(col.Name)
+	}
+	for _, card := range board.Cards {
+		n := moved[card.ID]
+		if n == nil {
+			if inFile[card.ID] || card.Column == "" {
+				continue
+			}
+			n = newMarkdownNode(card)
+		}
+		s := d.section(card.Column)
+		s.insert(n, s == d.sections[len(d.sections)-1])
+	}
+}
+
+// section returns the section for a column, adding a heading at the end of the file if needed
+func (d *mdDocument) section(name string) *mdSection {
+	for _, s := range d.sections {
+		if strings.EqualFold(s.name, name) {
+			return s
+		}
+	}
+
+	// Separate the new heading from what comes before it
+	if n := len(d.sections); n > 0 {
+		last := d.sections[n-1]
+		if len(last.nodes) == 0 || !last.nodes[len(last.nodes)-1].blank() {
+			last.nodes = append(last.nodes, &mdNode{lines: []string{""}})
+		}
+	} else if n := len(d.preamble); n > 0 && strings.TrimSpace(d.preamble[n-1]) != "" {
+		d.preamble = append(d.preamble, "")
+	}
+
+	s := &mdSection{heading: "## " + name, name: name}
+	d.sections = append(d.sections, s)
+	return s
+}
+
+// insert adds an item after the section's last item, or after its text if it has none
+func (s *mdSection) insert(n *mdNode, last bool) {
+	i := -1
+	for j, node := range s.nodes {
+		if node.item {
+			i = j + 1
+		}
+	}
+	if i < 0 {
+		// Start the list after a blank line
+		i = 0
+		for j, node := range s.nodes {
+			if !node.blank() {
+				i = j + 1
+			}
+		}
+		if i < len(s.nodes) {
+			i++
+		} else {
+			s.nodes = append(s.nodes, &mdNode{lines: []string{""}})
+			i = len(s.nodes)
+		}
+	}
+
+	s.nodes = slices.Insert(s.nodes, i, n)
+	if i == len(s.nodes)-1 && !last {
+		s.nodes = append(s.nodes, &mdNode{lines: []string{""}})
+	}
+}
+
+// update rewrites an item for a changed card, keeping the original bullet and any
+// ID comment; the lines are left untouched if the card is unchanged
+// A moved item is checked if it moved to DONE or ARCHIVE and unchecked otherwise
+func (n *mdNode) update(card *Card, moved bool) {
+	if moved {
+		n.lines[0] = n.lines[0][:3] + mdCheck(card.Column) + n.lines[0][4:]
+	}
+
+	unchanged := *card
+	unchanged.Column = n.card.Column
+	if !cardsEqual(&n.card, &unchanged) {
+		text := markdownItemText(card)
+		if n.idComment {
+			text += fmt.Sprintf(" <!-- id:%s -->", n.id)
+		}
+		n.lines[0] = n.lines[0][:len("- [ ] ")] + text
+		if card.Description != n.card.Description {
+			n.lines = append(n.lines[:1], mdIndent(card.Description)...)
+		}
+	}
+
+	n.card = *card
+}
+
+// newMarkdownNode renders a card that isn't in the file yet
+func newMarkdownNode(card *Card) *mdNode {
+	line := fmt.Sprintf("- [%s] %s", mdCheck(card.Column), markdownItemText(card))
+	return &mdNode{
+		lines: append([]string{line}, mdIndent(card.Description)...),
+		item:  true,
+		id:    card.ID,
+		card:  *card,
+	}
+}
+
+// mdCheck returns the checkbox state for an item in a column
+func mdCheck(column string) string {
+	if strings.EqualFold(column, "DONE") || strings.EqualFold(column, "ARCHIVE") {
+		return "x"
+	}
+	return " "
+}
+
+// mdIndent returns a description as indented item continuation lines
+// Blank lines are left empty rather than indented, so editors don't strip them
+func mdIndent(description string) []string {
+	if description == "" {
+		return nil
+	}
+	lines := strings.Split(description, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = "  " + line
+		}
+	}
+	return lines
+}
+
+// render returns the document as file content
+func (d *mdDocument) render() string {
+	lines := append([]string{}, d.preamble...)
+	for _, s := range d.sections {
+		lines = append(lines, s.heading)
+		for _, n := range s.nodes {
+			lines = append(lines, n.lines...)
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	eol := "\n"
+	if d.crlf {
+		eol = "\r\n"
+	}
+	text := strings.Join(lines, eol)
+	if d.newline {
+		text += eol
+	}
+	return text
+}
+
+// isMarkdownTaskList reports whether a Markdown file has at least one column heading
+// with a checklist item under it
+func isMarkdownTaskList(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return len(parseMarkdownDocument(string(data)).items()) > 0
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// copyMarkdownFixture copies testdata/markdown/TODO.md into a temporary directory
+func copyMarkdownFixture(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "markdown", "TODO.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "TODO.md")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestMarkdownBackendLoad(t *testing.T) {
+	backend := NewMarkdownBackend(copyMarkdownFixture(t))
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if board.Name != "Project tasks" {
+		t.Errorf("name = %q", board.Name)
+	}
+	var columns []string
+	for _, col := range board.Columns {
+		columns = append(columns, col.Name)
+	}
+	if !slices.Equal(columns, []string{"Next", "Doing", "Done"}) {
+		t.Errorf("columns = %v", columns)
+	}
+
+	var ids []string
+	for _, card := range board.Cards {
+		ids = append(ids, card.ID+":"+card.Title)
+	}
+	want := []string{"1:Write the release notes", "2:Fix flaky upload test", "42:Support proxies", "3:Profile startup", "4:Ship 1.0"}
+	if !slices.Equal(ids, want) {
+		t.Errorf("cards = %v, want %v", ids, want)
+	}
+
+	notes := board.FindCard("1")
+	if notes.Assignee != "@alice" || notes.DueDate != "2026-11-02" || !slices.Equal(notes.Tags, []string{"docs"}) {
+		t.Errorf("release notes metadata = %q %q %v", notes.Assignee, notes.DueDate, notes.Tags)
+	}
+	if got := board.FindCard("2").Description; got != "Fails about once a week on CI.\nSee the retry logic in upload.go." {
+		t.Errorf("description = %q", got)
+	}
+}
+
+func TestMarkdownBackendSaveUnchangedIsLossless(t *testing.T) {
+	path := copyMarkdownFixture(t)
+	original, _ := os.ReadFile(path)
+
+	backend := NewMarkdownBackend(path)
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, _ := os.ReadFile(path)
+	if string(saved) != string(original) {
+		t.Errorf("saving an unchanged board rewrote the file:\n%s", saved)
+	}
+}
+
+func TestMarkdownBackendEdits(t *testing.T) {
+	path := copyMarkdownFixture(t)
+	backend := NewMarkdownBackend(path)
+
+	if err := backend.DeleteCard("1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.MoveCard("2", "Done"); err != nil {
+		t.Fatal(err)
+	}
+
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxies := board.FindCard("42")
+	proxies.Title = "Support HTTP proxies"
+	proxies.Tags = []string{"net"}
+	if err := backend.UpdateCard(proxies); err != nil {
+		t.Fatal(err)
+	}
+
+	card, err := backend.CreateCard("Add dark mode", "Follows the system setting.", "Doing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.ID != "43" {
+		t.Errorf("new card ID = %s, want 43", card.ID)
+	}
+
+	// IDs stay put after the delete: 3 is still "Profile startup"
+	if err := backend.MoveCard("3", "Later"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := os.ReadFile(path)
+	want, err := os.ReadFile(filepath.Join("testdata", "markdown", "TODO.edited.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("edited file:\n%s\nwant:\n%s", got, want)
+	}
+
+	// A fresh backend reads the edits back
+	board, err = NewMarkdownBackend(path).LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := map[string]string{}
+	for _, card := range board.Cards {
+		columns[card.Title] = card.Column
+	}
+	if columns["Fix flaky upload test"] != "Done" || columns["Profile startup"] != "Later" || columns["Add dark mode"] != "Doing" {
+		t.Errorf("columns after reload = %v", columns)
+	}
+}
+
+func TestMarkdownBackendMetadataRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TODO.md")
+	if err := os.WriteFile(path, []byte("## TODO\n\n- [ ] Task one\n- [ ] Task two\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	backend := NewMarkdownBackend(path)
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	one, two := board.Cards[0], board.Cards[1]
+	one.Assignee = "alice"
+	one.Tags = []string{"needs review", "p1"}
+	two.Assignee = "@alice, @bob"
+	two.DueDate = "Jan 2, 2027"
+	if err := backend.UpdateCard(one); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.UpdateCard(two); err != nil {
+		t.Fatal(err)
+	}
+
+	board, err = NewMarkdownBackend(path).LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	one, two = board.Cards[0], board.Cards[1]
+	if one.Title != "Task one" || one.Assignee != "@alice" || !slices.Equal(one.Tags, []string{"needs-review", "p1"}) {
+		t.Errorf("card one reloaded as %q, assignee %q, tags %v", one.Title, one.Assignee, one.Tags)
+	}
+	if two.Title != "Task two" || two.Assignee != "@alice, @bob" || two.DueDate != "2027-01-02" {
+		t.Errorf("card two reloaded as %q, assignee %q, due %q", two.Title, two.Assignee, two.DueDate)
+	}
+}
+
+func TestMarkdownBackendBlankDescriptionLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TODO.md")
+	if err := os.WriteFile(path, []byte("## TODO\n\n- [ ] First\n\n- [ ] Second\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	backend := NewMarkdownBackend(path)
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := board.FindCard("1")
+	first.Description = "para one\n\npara two"
+	if err := backend.UpdateCard(first); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := os.ReadFile(path)
+	want := "## TODO\n\n- [ ] First\n  para one\n\n  para two\n\n- [ ] Second\n"
+	if string(got) != want {
+		t.Errorf("file =\n%s\nwant:\n%s", got, want)
+	}
+
+	// Blank lines indented by an editor read back the same way
+	indented := strings.Replace(string(got), "one\n\n", "one\n  \n", 1)
+	for _, content := range []string{string(got), indented} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		board, err := NewMarkdownBackend(path).LoadBoard()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(board.Cards) != 2 || board.Cards[1].Title != "Second" {
+			t.Fatalf("cards = %+v", board.Cards)
+		}
+		if got := board.Cards[0].Description; got != "para one\n\npara two" {
+			t.Errorf("description = %q", got)
+		}
+	}
+}
+
+func TestScanProjectsFindsMarkdownTaskLists(t *testing.T) {
+	root := t.TempDir()
+	for dir, content := range map[string]string{
+		"app":   "# App\n\n## TODO\n\n- [ ] Ship it\n",
+		"notes": "# Notes\n\n## Ideas\n\nNo checklist here.\n",
+	} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, dir, "TODO.md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	projects, err := ScanProjects(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 || projects[0].Name != "App" || filepath.Base(projects[0].Path) != "TODO.md" {
+		t.Errorf("projects = %+v", projects)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RESTAPIError is a non-2xx response from a REST API (Gitea, Forgejo or GitLab)
+type RESTAPIError struct {
+	Service    string // e.g. "Gitea"
+	StatusCode int
+	Message    string
+}
+
+func (e *RESTAPIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%s API error (%d): %s", e.Service, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%s API error (%d)", e.Service, e.StatusCode)
+}
+
+// restClient sends JSON requests to a token-authenticated REST API
+type restClient struct {
+	service    string // Names the API in errors
+	baseURL    string
+	authHeader string // Header carrying the token (e.g. Authorization or PRIVATE-TOKEN)
+	authValue  string // Empty to send no credentials
+	http       *http.Client
+}
+
+// newRESTClient creates a client for the API at baseURL
+func newRESTClient(service, baseURL, authHeader, authValue string) *restClient {
+	return &restClient{
+		service:    service,
+		baseURL:    baseURL,
+		authHeader: authHeader,
+		authValue:  authValue,
+		http:       &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into out (if non-nil)
+// Returns the response headers (for pagination)
+func (c *restClient) do(method, path string, body, out interface{}) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.authValue != "" {
+		req.Header.Set(c.authHeader, c.authValue)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &RESTAPIError{Service: c.service, StatusCode: resp.StatusCode, Message: restErrorMessage(data)}
+	}
+
+	if out == nil || len(data) == 0 {
+		return resp.Header, nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.Header, nil
+}
+
+// restErrorMessage extracts the message from an error body
+// Gitea sends {"message": "..."}; GitLab also sends {"error": "..."} and
+// validation errors as {"message": {"field": ["..."]}}
+func restErrorMessage(data []byte) string {
+	var body struct {
+		Message json.RawMessage `json:"message"`
+		Error   string          `json:"error"`
+	}
+	if json.Unmarshal(data, &body) != nil {
+		return ""
+	}
+	var text string
+	if json.Unmarshal(body.Message, &text) == nil {
+		return text
+	}
+	if len(body.Message) > 0 {
+		return string(body.Message)
+	}
+	return body.Error
+}
@@ -0,0 +1,620 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchemaVersion is stored in PRAGMA user_version; bump it and add a step to
+// migrateSQLiteSchema when the schema changes
+const sqliteSchemaVersion = 1
+
+// sqliteSchema creates the tables of a board database
+// Cards keep their board order in position; tags are a table of their own so
+// they can be indexed
+const sqliteSchema = `
+CREATE TABLE board (
+	id          INTEGER PRIMARY KEY CHECK (id = 1),
+	name        TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	url         TEXT NOT NULL DEFAULT '',
+	settings    TEXT NOT NULL DEFAULT '', -- Templates, recurring rules and sync state as YAML
+	created_at  TEXT NOT NULL,
+	modified_at TEXT NOT NULL
+);
+
+CREATE TABLE columns (
+	position INTEGER PRIMARY KEY,
+	name     TEXT NOT NULL
+);
+
+CREATE TABLE cards (
+	id           TEXT PRIMARY KEY,
+	position     INTEGER NOT NULL,
+	title        TEXT NOT NULL,
+	description  TEXT NOT NULL DEFAULT '',
+	column_name  TEXT NOT NULL,
+	assignee     TEXT NOT NULL DEFAULT '',
+	due_date     TEXT NOT NULL DEFAULT '',
+	url          TEXT NOT NULL DEFAULT '',
+	content_type TEXT NOT NULL DEFAULT '',
+	content_id   TEXT NOT NULL DEFAULT '',
+	remote_id    TEXT NOT NULL DEFAULT '',
+	created_at   TEXT NOT NULL,
+	modified_at  TEXT NOT NULL
+);
+CREATE INDEX cards_position ON cards (position);
+CREATE INDEX cards_column ON cards (column_name, position);
+CREATE INDEX cards_due_date ON cards (due_date) WHERE due_date != '';
+
+CREATE TABLE card_tags (
+	card_id  TEXT NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	tag      TEXT NOT NULL,
+	PRIMARY KEY (card_id, position)
+);
+CREATE INDEX card_tags_tag ON card_tags (tag);
+`
+
+// sqliteCardColumns are the cards columns read by scanSQLiteCard, in order
+const sqliteCardColumns = `id, title, description, column_name, assignee, due_date, url,
+	content_type, content_id, remote_id, created_at, modified_at`
+
+// SQLiteBackend implements Backend using a SQLite database (.tkan.db)
+// Card changes touch only the card's rows, so large boards don't have to be
+// rewritten on every move or edit
+type SQLiteBackend struct {
+	filePath string
+
+	mu     sync.Mutex
+	db     *sql.DB         // Opened on first use
+	err    error           // Error from opening db
+	loaded map[string]bool // Cards last loaded or saved; SaveBoard removes only these
+}
+
+// NewSQLiteBackend creates a backend for the board database at filePath
+// The database is opened (and created, when saving) on first use
+func NewSQLiteBackend(filePath string) *SQLiteBackend {
+	return &SQLiteBackend{filePath: filePath}
+}
+
+// isSQLiteBoard reports whether a board path is a SQLite database
+func isSQLiteBoard(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".db", ".sqlite", ".sqlite3":
+		return true
+	}
+	return false
+}
+
+// open returns the database, opening it and bringing its schema up to date the
+// first time; a missing file is created only if create is set
+func (s *SQLiteBackend) open(create bool) (*sql.DB, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db != nil || s.err != nil {
+		return s.db, s.err
+	}
+	if _, err := os.Stat(s.filePath); err != nil && !create {
+		return nil, fmt.Errorf("failed to read board file: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", s.filePath+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err == nil {
+		// One connection: SQLite allows a single writer, and the pragmas apply per connection
+		db.SetMaxOpenConns(1)
+		err = migrateSQLiteSchema(db)
+		if err != nil {
+			db.Close()
+		}
+	}
+	if err != nil {
+		s.err = fmt.Errorf("failed to open board database %s: %w", s.filePath, err)
+		return nil, s.err
+	}
+	s.db = db
+	return db, nil
+}
+
+// Close closes the database
+func (s *SQLiteBackend) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db = nil
+	return err
+}
+
+// migrateSQLiteSchema creates the tables of a new database or upgrades an older one
+func migrateSQLiteSchema(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	if version > sqliteSchemaVersion {
+		return fmt.Errorf("database schema version %d is newer than this tkan supports (%d)", version, sqliteSchemaVersion)
+	}
+	if version == sqliteSchemaVersion {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if version == 0 {
+		if _, err := tx.Exec(sqliteSchema); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", sqliteSchemaVersion)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// sqliteSettings holds the board fields stored as YAML in board.settings
+type sqliteSettings struct {
+	Templates []CardTemplate   `yaml:"templates,omitempty"`
+	Recurring []RecurrenceRule `yaml:"recurring,omitempty"`
+	Sync      *SyncState       `yaml:"sync,omitempty"`
+}
+
+// sqliteTime formats a timestamp for storage
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// parseSQLiteTime parses a stored timestamp (zero if empty or malformed)
+func parseSQLiteTime(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, s)
+	return t
+}
+
+// LoadBoard reads the board, its columns and all cards
+func (s *SQLiteBackend) LoadBoard() (*Board, error) {
+	db, err := s.open(false)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &Board{Cards: []*Card{}}
+	var settings, created, modified string
+	err = db.QueryRow("SELECT name, description, url, settings, created_at, modified_at FROM board WHERE id = 1").
+		Scan(&board.Name, &board.Description, &board.URL, &settings, &created, &modified)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s has no board", s.filePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read board: %w", err)
+	}
+	board.CreatedAt, board.ModifiedAt = parseSQLiteTime(created), parseSQLiteTime(modified)
+
+	var extra sqliteSettings
+	if err := yaml.Unmarshal([]byte(settings), &extra); err != nil {
+		return nil, fmt.Errorf("failed to parse board settings: %w", err)
+	}
+	board.Templates, board.Recurring, board.Sync = extra.Templates, extra.Recurring, extra.Sync
+
+	rows, err := db.Query("SELECT name FROM columns ORDER BY position")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var col Column
+		if err := rows.Scan(&col.Name); err != nil {
+			return nil, err
+		}
+		board.Columns = append(board.Columns, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	board.Cards, err = s.queryCards(db, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	board.PopulateColumnCards()
+	s.setLoaded(board.Cards)
+	return board, nil
+}
+
+// setLoaded records the cards of a board as loaded or saved
+func (s *SQLiteBackend) setLoaded(cards []*Card) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.loaded = map[string]bool{}
+	for _, card := range cards {
+		s.loaded[card.ID] = true
+	}
+}
+
+// wasLoaded reports whether a card was on the board last loaded or saved
+func (s *SQLiteBackend) wasLoaded(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loaded[id]
+}
+
+// BoardName returns the board's name without loading its cards
+func (s *SQLiteBackend) BoardName() (string, error) {
+	db, err := s.open(false)
+	if err != nil {
+		return "", err
+	}
+	var name string
+	if err := db.QueryRow("SELECT name FROM board WHERE id = 1").Scan(&name); err != nil {
+		return "", fmt.Errorf("failed to read board: %w", err)
+	}
+	return name, nil
+}
+
+// QueryCards selects cards with indexed queries instead of loading the whole board
+func (s *SQLiteBackend) QueryCards(query CardQuery) ([]*Card, error) {
+	db, err := s.open(false)
+	if err != nil {
+		return nil, err
+	}
+
+	var conds []string
+	var args []interface{}
+	if query.Column != "" {
+		conds = append(conds, "column_name = ?")
+		args = append(args, query.Column)
+	}
+	if len(query.ExcludeColumns) > 0 {
+		conds = append(conds, "column_name NOT IN (?"+strings.Repeat(", ?", len(query.ExcludeColumns)-1)+")")
+		for _, col := range query.ExcludeColumns {
+			args = append(args, col)
+		}
+	}
+	if query.Tag != "" {
+		conds = append(conds, "id IN (SELECT card_id FROM card_tags WHERE tag = ?)")
+		args = append(args, query.Tag)
+	}
+	if query.HasDueDate || query.DueBy != "" {
+		conds = append(conds, "due_date != ''")
+	}
+
+	cards, err := s.queryCards(db, strings.Join(conds, " AND "), args)
+	if err != nil || query.DueBy == "" {
+		return cards, err
+	}
+
+	// Due dates may be in any layout ParseDueDate accepts, so they can't be compared in SQL
+	due := cards[:0]
+	for _, card := range cards {
+		if query.Matches(card) {
+			due = append(due, card)
+		}
+	}
+	return due, nil
+}
+
+// queryCards reads the cards matching a WHERE clause (all cards if empty), with their tags
+func (s *SQLiteBackend) queryCards(db *sql.DB, where string, args []interface{}) ([]*Card, error) {
+	cardQuery := "SELECT " + sqliteCardColumns + " FROM cards"
+	tagQuery := "SELECT card_id, tag FROM card_tags"
+	if where != "" {
+		cardQuery += " WHERE " + where
+		tagQuery += " WHERE card_id IN (SELECT id FROM cards WHERE " + where + ")"
+	}
+	cardQuery += " ORDER BY position"
+	tagQuery += " ORDER BY card_id, position"
+
+	rows, err := db.Query(cardQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cards: %w", err)
+	}
+	defer rows.Close()
+
+	cards := []*Card{}
+	byID := map[string]*Card{}
+	for rows.Next() {
+		card, err := scanSQLiteCard(rows)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+		byID[card.ID] = card
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tagRows, err := db.Query(tagQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tags: %w", err)
+	}
+	defer tagRows.Close()
+	for tagRows.Next() {
+		var id, tag string
+		if err := tagRows.Scan(&id, &tag); err != nil {
+			return nil, err
+		}
+		if card := byID[id]; card != nil {
+			card.Tags = append(card.Tags, tag)
+		}
+	}
+	return cards, tagRows.Err()
+}
+
+// scanSQLiteCard reads a row of sqliteCardColumns
+func scanSQLiteCard(rows *sql.Rows) (*Card, error) {
+	card := &Card{}
+	var created, modified string
+	err := rows.Scan(&card.ID, &card.Title, &card.Description, &card.Column, &card.Assignee, &card.DueDate,
+		&card.URL, &card.ContentType, &card.ContentID, &card.RemoteID, &created, &modified)
+	if err != nil {
+		return nil, err
+	}
+	card.CreatedAt, card.ModifiedAt = parseSQLiteTime(created), parseSQLiteTime(modified)
+	return card, nil
+}
+
+// SaveBoard replaces the stored board with board, creating the database if needed
+// Cards stored since the board was loaded (by tkan add, say) are kept; only cards
+// that were loaded and are gone from board are removed
+func (s *SQLiteBackend) SaveBoard(board *Board) error {
+	db, err := s.open(true)
+	if err != nil {
+		return err
+	}
+	board.ModifiedAt = time.Now()
+
+	settings, err := yaml.Marshal(sqliteSettings{Templates: board.Templates, Recurring: board.Recurring, Sync: board.Sync})
+	if err != nil {
+		return fmt.Errorf("failed to marshal board settings: %w", err)
+	}
+	if string(settings) == "{}\n" {
+		settings = nil
+	}
+
+	// Only cards that changed or moved are written, so saving after a single edit stays cheap
+	stored, err := s.storedCards(db)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT INTO board (id, name, description, url, settings, created_at, modified_at)
+		VALUES (1, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET name = excluded.name, description = excluded.description, url = excluded.url,
+			settings = excluded.settings, created_at = excluded.created_at, modified_at = excluded.modified_at`,
+		board.Name, board.Description, board.URL, string(settings), sqliteTime(board.CreatedAt), sqliteTime(board.ModifiedAt))
+	if err != nil {
+		return fmt.Errorf("failed to save board: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM columns"); err != nil {
+		return err
+	}
+	for i, col := range board.Columns {
+		if _, err := tx.Exec("INSERT INTO columns (position, name) VALUES (?, ?)", i, col.Name); err != nil {
+			return fmt.Errorf("failed to save column %s: %w", col.Name, err)
+		}
+	}
+
+	saved := map[string]bool{}
+	for i, card := range board.Cards {
+		saved[card.ID] = true
+		if old, ok := stored[card.ID]; ok && old.position == i && sqliteCardEqual(old.card, card) {
+			continue
+		}
+		if err := upsertSQLiteCard(tx, card, i); err != nil {
+			return err
+		}
+	}
+	for id := range stored {
+		if !saved[id] && s.wasLoaded(id) {
+			if _, err := tx.Exec("DELETE FROM cards WHERE id = ?", id); err != nil {
+				return fmt.Errorf("failed to remove card %s: %w", id, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	s.setLoaded(board.Cards)
+	return nil
+}
+
+// sqliteStoredCard is a card as stored, with its board position
+type sqliteStoredCard struct {
+	card     *Card
+	position int
+}
+
+// storedCards reads all stored cards by ID
+func (s *SQLiteBackend) storedCards(db *sql.DB) (map[string]sqliteStoredCard, error) {
+	cards, err := s.queryCards(db, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	positions := map[string]int{}
+	rows, err := db.Query("SELECT id, position FROM cards")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var position int
+		if err := rows.Scan(&id, &position); err != nil {
+			return nil, err
+		}
+		positions[id] = position
+	}
+
+	stored := map[string]sqliteStoredCard{}
+	for _, card := range cards {
+		stored[card.ID] = sqliteStoredCard{card: card, position: positions[card.ID]}
+	}
+	return stored, rows.Err()
+}
+
+// sqliteCardEqual reports whether two versions of a card have the same stored fields
+func sqliteCardEqual(a, b *Card) bool {
+	return cardsEqual(a, b) &&
+		a.ContentType == b.ContentType &&
+		a.ContentID == b.ContentID &&
+		a.RemoteID == b.RemoteID &&
+		sqliteTime(a.CreatedAt) == sqliteTime(b.CreatedAt) &&
+		sqliteTime(a.ModifiedAt) == sqliteTime(b.ModifiedAt)
+}
+
+// upsertSQLiteCard writes a card and its tags at the given board position
+func upsertSQLiteCard(tx *sql.Tx, card *Card, position int) error {
+	_, err := tx.Exec(`INSERT INTO cards (`+sqliteCardColumns+`, position)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET title = excluded.title, description = excluded.description,
+			column_name = excluded.column_name, assignee = excluded.assignee, due_date = excluded.due_date,
+			url = excluded.url, content_type = excluded.content_type, content_id = excluded.content_id,
+			remote_id = excluded.remote_id, created_at = excluded.created_at, modified_at = excluded.modified_at,
+			position = excluded.position`,
+		card.ID, card.Title, card.Description, card.Column, card.Assignee, card.DueDate, card.URL,
+		card.ContentType, card.ContentID, card.RemoteID, sqliteTime(card.CreatedAt), sqliteTime(card.ModifiedAt), position)
+	if err != nil {
+		return fmt.Errorf("failed to save card %s: %w", card.ID, err)
+	}
+	return replaceSQLiteTags(tx, card)
+}
+
+// replaceSQLiteTags replaces a card's tags
+func replaceSQLiteTags(tx *sql.Tx, card *Card) error {
+	if _, err := tx.Exec("DELETE FROM card_tags WHERE card_id = ?", card.ID); err != nil {
+		return err
+	}
+	for i, tag := range card.Tags {
+		if _, err := tx.Exec("INSERT INTO card_tags (card_id, position, tag) VALUES (?, ?, ?)", card.ID, i, tag); err != nil {
+			return fmt.Errorf("failed to save tags of card %s: %w", card.ID, err)
+		}
+	}
+	return nil
+}
+
+// update runs fn in a transaction and bumps the board's modification time
+func (s *SQLiteBackend) update(fn func(tx *sql.Tx, now time.Time) error) error {
+	db, err := s.open(false)
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if err := fn(tx, now); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE board SET modified_at = ? WHERE id = 1", sqliteTime(now)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// execOne runs a statement that must change exactly the card cardID
+func execOne(tx *sql.Tx, cardID, query string, args ...interface{}) error {
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("card not found: %s", cardID)
+	}
+	return nil
+}
+
+// MoveCard moves a card to a different column
+func (s *SQLiteBackend) MoveCard(cardID string, toColumn string) error {
+	return s.update(func(tx *sql.Tx, now time.Time) error {
+		return execOne(tx, cardID, "UPDATE cards SET column_name = ?, modified_at = ? WHERE id = ?",
+			toColumn, sqliteTime(now), cardID)
+	})
+}
+
+// UpdateCard updates a card's details
+func (s *SQLiteBackend) UpdateCard(card *Card) error {
+	return s.update(func(tx *sql.Tx, now time.Time) error {
+		err := execOne(tx, card.ID, `UPDATE cards SET title = ?, description = ?, column_name = ?, assignee = ?,
+			due_date = ?, url = ?, content_type = ?, content_id = ?, remote_id = ?, modified_at = ? WHERE id = ?`,
+			card.Title, card.Description, card.Column, card.Assignee, card.DueDate, card.URL,
+			card.ContentType, card.ContentID, card.RemoteID, sqliteTime(card.ModifiedAt), card.ID)
+		if err != nil {
+			return err
+		}
+		return replaceSQLiteTags(tx, card)
+	})
+}
+
+// CreateCard adds a card at the end of the board
+func (s *SQLiteBackend) CreateCard(title, description, column string) (*Card, error) {
+	card := &Card{
+		Title:       title,
+		Description: description,
+		Column:      column,
+		CreatedAt:   time.Now(),
+		ModifiedAt:  time.Now(),
+	}
+
+	err := s.update(func(tx *sql.Tx, now time.Time) error {
+		// Same numbering as Board.NextCardID: one more than the largest numeric ID
+		var maxID, position int
+		err := tx.QueryRow("SELECT COALESCE(MAX(CAST(id AS INTEGER)), 0), COALESCE(MAX(position), -1) + 1 FROM cards").
+			Scan(&maxID, &position)
+		if err != nil {
+			return err
+		}
+		card.ID = fmt.Sprintf("%d", maxID+1)
+		return upsertSQLiteCard(tx, card, position)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if s.loaded != nil {
+		s.loaded[card.ID] = true
+	}
+	s.mu.Unlock()
+	return card, nil
+}
+
+// DeleteCard removes a card from the board
+// Use MoveCard(cardID, "ARCHIVE") to archive a card instead
+func (s *SQLiteBackend) DeleteCard(cardID string) error {
+	err := s.update(func(tx *sql.Tx, now time.Time) error {
+		_, err := tx.Exec("DELETE FROM cards WHERE id = ?", cardID)
+		return err
+	})
+	s.mu.Lock()
+	delete(s.loaded, cardID)
+	s.mu.Unlock()
+	return err
+}
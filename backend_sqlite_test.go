@@ -0,0 +1,280 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// sqliteTestBoard is a board with the fields a migration must carry over
+func sqliteTestBoard() *Board {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	board := &Board{
+		Name:        "Big board",
+		Description: "Lots of cards",
+		Columns:     []Column{{Name: "TODO"}, {Name: "PROGRESS"}, {Name: "DONE"}},
+		Cards: []*Card{
+			{ID: "1", Title: "Fix login", Tags: []string{"bug", "p1"}, Assignee: "@alice", DueDate: "2026-02-01", Column: "TODO", CreatedAt: created, ModifiedAt: created},
+			{ID: "2", Title: "Add OAuth", Description: "Token refresh", Column: "PROGRESS", URL: "https://example.com/2", CreatedAt: created, ModifiedAt: created},
+			{ID: "3", Title: "Ship", Tags: []string{"release"}, DueDate: "2026-01-10", Column: "DONE", CreatedAt: created, ModifiedAt: created},
+		},
+		Templates: []CardTemplate{{Name: "bug", Title: "Bug: ", Tags: []string{"bug"}}},
+		Recurring: []RecurrenceRule{{Name: "weekly", Schedule: "weekly:mon", Template: "bug", Column: "TODO"}},
+		CreatedAt: created,
+	}
+	board.PopulateColumnCards()
+	return board
+}
+
+func TestMigrateBoardRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, ".tkan.yaml")
+	if err := SaveBoard(yamlPath, sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+
+	dbPath, err := migrateDestination(yamlPath, "")
+	if err != nil || dbPath != filepath.Join(dir, ".tkan.db") {
+		t.Fatalf("destination = %s, %v", dbPath, err)
+	}
+	if _, err := MigrateBoard(yamlPath, dbPath, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MigrateBoard(yamlPath, dbPath, false); err == nil {
+		t.Error("migrating onto an existing board should need --force")
+	}
+
+	backYAML := filepath.Join(dir, "back.tkan.yaml")
+	if _, err := MigrateBoard(dbPath, backYAML, false); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := LoadBoard(yamlPath)
+	got, err := LoadBoard(backYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Name != want.Name || got.Description != want.Description || len(got.Columns) != len(want.Columns) {
+		t.Errorf("board = %+v", got)
+	}
+	if len(got.Templates) != 1 || len(got.Recurring) != 1 || got.Recurring[0].Schedule != "weekly:mon" {
+		t.Errorf("templates = %+v, recurring = %+v", got.Templates, got.Recurring)
+	}
+	if len(got.Cards) != len(want.Cards) {
+		t.Fatalf("got %d cards, want %d", len(got.Cards), len(want.Cards))
+	}
+	for i, card := range got.Cards {
+		if !cardsEqual(card, want.Cards[i]) || card.ID != want.Cards[i].ID || !card.CreatedAt.Equal(want.Cards[i].CreatedAt) {
+			t.Errorf("card %d = %+v, want %+v", i, card, want.Cards[i])
+		}
+	}
+}
+
+func TestSQLiteBackendCardOperations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tkan.db")
+	backend := NewSQLiteBackend(path)
+	defer backend.Close()
+	if err := backend.SaveBoard(sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+
+	card, err := backend.CreateCard("Write docs", "", "TODO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.ID != "4" {
+		t.Errorf("new card ID = %s, want 4", card.ID)
+	}
+	if err := backend.MoveCard("1", "PROGRESS"); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.MoveCard("99", "DONE"); err == nil {
+		t.Error("moving a missing card should fail")
+	}
+
+	card.Tags = []string{"docs"}
+	card.Assignee = "@bob"
+	if err := backend.UpdateCard(card); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.DeleteCard("2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second connection sees the changes
+	other := NewSQLiteBackend(path)
+	defer other.Close()
+	board, err := other.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cards []string
+	for _, c := range board.Cards {
+		cards = append(cards, c.ID+":"+c.Column)
+	}
+	if !slices.Equal(cards, []string{"1:PROGRESS", "3:DONE", "4:TODO"}) {
+		t.Errorf("cards = %v", cards)
+	}
+	if docs := board.FindCard("4"); docs.Assignee != "@bob" || !slices.Equal(docs.Tags, []string{"docs"}) {
+		t.Errorf("updated card = %+v", docs)
+	}
+}
+
+func TestSQLiteBackendQueryCards(t *testing.T) {
+	backend := NewSQLiteBackend(filepath.Join(t.TempDir(), ".tkan.db"))
+	defer backend.Close()
+	board := sqliteTestBoard()
+	// Hand-edited boards may use other due date layouts
+	board.Cards = append(board.Cards, &Card{ID: "4", Title: "Renew domain", DueDate: "Jan 2, 2020", Column: "TODO", CreatedAt: board.CreatedAt, ModifiedAt: board.CreatedAt})
+	board.PopulateColumnCards()
+	if err := backend.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+	if due, _ := QueryCards(backend, nil, CardQuery{DueBy: "2026-01-31"}); len(due) != 2 || due[1].ID != "4" {
+		t.Errorf("cards due by 2026-01-31 = %v, want 3 and 4", due)
+	}
+
+	queries := []CardQuery{
+		{Tag: "bug"},
+		{Column: "PROGRESS"},
+		{ExcludeColumns: []string{"DONE"}},
+		{HasDueDate: true},
+		{DueBy: "2026-01-31"},
+		{HasDueDate: true, ExcludeColumns: []string{"DONE", "ARCHIVE"}},
+	}
+	for _, query := range queries {
+		got, err := QueryCards(backend, nil, query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The in-memory filter used for YAML boards must agree with SQL
+		want, _ := QueryCards(NewLocalBackend(""), board, query)
+
+		var gotIDs, wantIDs []string
+		for _, card := range got {
+			gotIDs = append(gotIDs, card.ID)
+		}
+		for _, card := range want {
+			wantIDs = append(wantIDs, card.ID)
+		}
+		if !slices.Equal(gotIDs, wantIDs) || len(gotIDs) == 0 {
+			t.Errorf("query %+v = %v, want %v", query, gotIDs, wantIDs)
+		}
+	}
+}
+
+func TestScanProjectsFindsSQLiteBoards(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "big")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	backend := NewSQLiteBackend(filepath.Join(dir, ".tkan.db"))
+	if err := backend.SaveBoard(sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+	backend.Close()
+
+	projects, err := ScanProjects(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 || projects[0].Name != "Big board" || filepath.Base(projects[0].Path) != ".tkan.db" {
+		t.Errorf("projects = %+v", projects)
+	}
+}
+
+func TestSwitchingProjectsClosesSQLiteBoard(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, ".tkan.db")
+	yamlPath := filepath.Join(dir, "other.tkan.yaml")
+	backend := NewSQLiteBackend(dbPath)
+	if err := backend.SaveBoard(sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveBoard(yamlPath, sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModelWithBackend(sqliteTestBoard(), []Project{{Path: dbPath}, {Path: yamlPath}}, backend)
+	m.selectedProject = 1
+	if _, err := m.loadSelectedProject(); err != nil {
+		t.Fatal(err)
+	}
+	if backend.db != nil {
+		t.Error("the SQLite board should be closed once another project is open")
+	}
+}
+
+func TestSwitchingToBrokenProjectKeepsCurrentBoard(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, ".tkan.db")
+	brokenPath := filepath.Join(dir, "broken.tkan.yaml")
+	backend := NewSQLiteBackend(dbPath)
+	defer backend.Close()
+	if err := backend.SaveBoard(sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(brokenPath, []byte("columns: [unterminated"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModelWithBackend(sqliteTestBoard(), []Project{{Path: dbPath}, {Path: brokenPath}}, backend)
+	m.selectedProject = 1
+	if _, err := m.loadSelectedProject(); err == nil {
+		t.Fatal("loading a broken board should fail")
+	}
+	if m.backend != Backend(backend) || backend.db == nil {
+		t.Fatal("a failed switch should keep the open board's backend")
+	}
+
+	// Edits still go to the open board
+	if err := m.backend.MoveCard("1", "DONE"); err != nil {
+		t.Fatal(err)
+	}
+	if board, _ := backend.LoadBoard(); board.FindCard("1").Column != "DONE" {
+		t.Error("the move didn't reach the open board")
+	}
+}
+
+func TestSQLiteBackendKeepsCardsAddedElsewhere(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tkan.db")
+	backend := NewSQLiteBackend(path)
+	defer backend.Close()
+	if err := backend.SaveBoard(sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+	board, err := backend.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModelWithBackend(board, nil, backend)
+
+	// tkan add runs while the board is open in the TUI
+	other := NewSQLiteBackend(path)
+	defer other.Close()
+	if _, err := other.CreateCard("From elsewhere", "", "TODO"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dragging a card, and saving the board with a card dropped, keep it
+	m.moveCard(0, 0, 1, 1)
+	board.Cards = board.Cards[1:]
+	board.PopulateColumnCards()
+	if err := backend.SaveBoard(board); err != nil {
+		t.Fatal(err)
+	}
+	after, err := other.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cards []string
+	for _, c := range after.Cards {
+		cards = append(cards, c.ID+":"+c.Column)
+	}
+	if !slices.Equal(cards, []string{"2:PROGRESS", "3:DONE", "4:TODO"}) {
+		t.Errorf("cards = %v", cards)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// boardCachePath returns the cache file for a remote board key (e.g. "github:github.com/acme/3")
+// Cached boards live in ~/.config/tkan/cache so remote projects open instantly
+func boardCachePath(key string) (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	name := strings.NewReplacer(":", "_", "/", "_", "\\", "_").Replace(key)
+	return filepath.Join(dir, "cache", name+".yaml"), nil
+}
+
+// SaveBoardCache stores the last fetched state of a remote board
+func SaveBoardCache(key string, board *Board) error {
+	path, err := boardCachePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return SaveBoard(path, board)
+}
+
+// LoadBoardCache returns the cached board for key and when it was fetched
+// Returns an error if nothing is cached
+func LoadBoardCache(key string) (*Board, time.Time, error) {
+	path, err := boardCachePath(key)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	board, err := LoadBoard(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return board, info.ModTime(), nil
+}
+
+// BoardDiff lists the cards that differ between two versions of a board
+type BoardDiff struct {
+	Added   []string // Card IDs only in the new board
+	Removed []string // Card IDs only in the old board
+	Changed []string // Card IDs whose content or column changed
+}
+
+// Empty reports whether the boards have the same cards
+func (d BoardDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffBoards compares the cards of two versions of a board by ID
+func DiffBoards(old, new *Board) BoardDiff {
+	var diff BoardDiff
+	oldCards := map[string]*Card{}
+	for _, card := range old.Cards {
+		oldCards[card.ID] = card
+	}
+
+	for _, card := range new.Cards {
+		prev, ok := oldCards[card.ID]
+		if !ok {
+			diff.Added = append(diff.Added, card.ID)
+			continue
+		}
+		delete(oldCards, card.ID)
+		if !cardsEqual(prev, card) {
+			diff.Changed = append(diff.Changed, card.ID)
+		}
+	}
+	for _, card := range old.Cards {
+		if _, ok := oldCards[card.ID]; ok {
+			diff.Removed = append(diff.Removed, card.ID)
+		}
+	}
+	return diff
+}
+
+// cardsEqual compares the user-visible fields of two cards
+func cardsEqual(a, b *Card) bool {
+	return a.Title == b.Title &&
+		a.Description == b.Description &&
+		a.Column == b.Column &&
+		a.Assignee == b.Assignee &&
+		a.DueDate == b.DueDate &&
+		a.URL == b.URL &&
+		strings.Join(a.Tags, ",") == strings.Join(b.Tags, ",")
+}
+
+// MergeRemoteBoard combines a freshly fetched board with the local one
+// The remote board wins, except for cards changed locally after since (the time
+// the fetch started), whose local version is kept so in-flight edits aren't undone,
+// and cards in deleted (card ID to when the delete finished) deleted after since,
+// which the fetch may still have seen
+func MergeRemoteBoard(local, remote *Board, since time.Time, deleted map[string]time.Time) *Board {
+	merged := *remote
+	merged.Cards = slices.DeleteFunc(append([]*Card{}, remote.Cards...), func(c *Card) bool {
+		at, ok := deleted[c.ID]
+		return ok && at.After(since)
+	})
+
+	for _, card := range local.Cards {
+		if !card.ModifiedAt.After(since) {
+			continue
+		}
+		replaced := false
+		for i, c := range merged.Cards {
+			if c.ID == card.ID {
+				merged.Cards[i] = card
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged.Cards = append(merged.Cards, card)
+		}
+	}
+
+	merged.Columns = make([]Column, len(remote.Columns))
+	copy(merged.Columns, remote.Columns)
+	merged.PopulateColumnCards()
+	return &merged
+}
+
+// formatSyncAge describes how long ago a board was synced ("synced 5s ago")
+func formatSyncAge(synced, now time.Time) string {
+	age := now.Sub(synced)
+	switch {
+	case age < time.Minute:
+		return fmt.Sprintf("synced %ds ago", int(age.Seconds()))
+	case age < time.Hour:
+		return fmt.Sprintf("synced %dm ago", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("synced %dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("synced %dd ago", int(age.Hours()/24))
+	}
+}
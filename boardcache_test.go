@@ -0,0 +1,233 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDiffBoards(t *testing.T) {
+	old := &Board{Cards: []*Card{
+		{ID: "1", Title: "Same", Column: "TODO"},
+		{ID: "2", Title: "Moves", Column: "TODO"},
+		{ID: "3", Title: "Goes away", Column: "TODO"},
+	}}
+	new := &Board{Cards: []*Card{
+		{ID: "1", Title: "Same", Column: "TODO"},
+		{ID: "2", Title: "Moves", Column: "DONE"},
+		{ID: "4", Title: "New", Column: "TODO"},
+	}}
+
+	diff := DiffBoards(old, new)
+	if !slices.Equal(diff.Added, []string{"4"}) || !slices.Equal(diff.Removed, []string{"3"}) || !slices.Equal(diff.Changed, []string{"2"}) {
+		t.Errorf("diff = %+v", diff)
+	}
+	if !DiffBoards(old, old).Empty() {
+		t.Error("a board should not differ from itself")
+	}
+}
+
+func TestMergeRemoteBoardKeepsLocalEditsMadeDuringFetch(t *testing.T) {
+	started := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	local := &Board{
+		Columns: []Column{{Name: "TODO"}, {Name: "DONE"}},
+		Cards: []*Card{
+			{ID: "1", Title: "Moved while fetching", Column: "DONE", ModifiedAt: started.Add(time.Second)},
+			{ID: "2", Title: "Stale", Column: "TODO", ModifiedAt: started.Add(-time.Hour)},
+		},
+	}
+	remote := &Board{
+		Columns: []Column{{Name: "TODO"}, {Name: "DONE"}},
+		Cards: []*Card{
+			{ID: "1", Title: "Moved while fetching", Column: "TODO"},
+			{ID: "2", Title: "Renamed remotely", Column: "TODO"},
+		},
+	}
+
+	merged := MergeRemoteBoard(local, remote, started, nil)
+	if card := merged.FindCard("1"); card.Column != "DONE" {
+		t.Errorf("card 1 column = %s, want the local DONE", card.Column)
+	}
+	if card := merged.FindCard("2"); card.Title != "Renamed remotely" {
+		t.Errorf("card 2 title = %q, want the remote title", card.Title)
+	}
+	if len(merged.Columns[1].Cards) != 1 {
+		t.Errorf("DONE column = %v", merged.Columns[1].Cards)
+	}
+}
+
+func TestMergeRemoteBoardDropsCardsDeletedDuringFetch(t *testing.T) {
+	started := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	local := &Board{Columns: []Column{{Name: "TODO"}}}
+	remote := &Board{
+		Columns: []Column{{Name: "TODO"}},
+		Cards: []*Card{
+			{ID: "1", Title: "Deleted while fetching", Column: "TODO"},
+			{ID: "2", Title: "Deleted before the fetch, recreated remotely", Column: "TODO"},
+		},
+	}
+	deleted := map[string]time.Time{"1": started.Add(time.Second), "2": started.Add(-time.Hour)}
+
+	merged := MergeRemoteBoard(local, remote, started, deleted)
+	if merged.FindCard("1") != nil {
+		t.Error("card 1 was deleted after the fetch started but came back")
+	}
+	if merged.FindCard("2") == nil || len(merged.Columns[0].Cards) != 1 {
+		t.Errorf("cards = %v, want only card 2", merged.Columns[0].Cards)
+	}
+}
+
+func TestGitHubBoardCacheRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	f := newFakeGitHub(t)
+	f.on("repositoryOwner", projectResponse)
+	f.on("items(first", func(map[string]interface{}) interface{} {
+		return map[string]interface{}{"node": map[string]interface{}{"items": map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": false},
+			"nodes": []interface{}{map[string]interface{}{
+				"id": "PVTI_1", "type": "DRAFT_ISSUE",
+				"content":     map[string]interface{}{"id": "DI_1", "title": "Cached card"},
+				"fieldValues": map[string]interface{}{"nodes": []interface{}{}},
+			}},
+		}}}
+	})
+	g := f.backend("acme", 3, "")
+	g.cacheBoards = true
+
+	if _, _, err := g.CachedBoard(); err == nil {
+		t.Fatal("CachedBoard before any load should fail")
+	}
+	if _, err := g.LoadBoard(); err != nil {
+		t.Fatalf("LoadBoard: %v", err)
+	}
+
+	cached, synced, err := g.CachedBoard()
+	if err != nil {
+		t.Fatalf("CachedBoard: %v", err)
+	}
+	if card := cached.FindCard("PVTI_1"); card == nil || card.Title != "Cached card" || card.ContentID != "DI_1" {
+		t.Errorf("cached cards = %+v", cached.Cards)
+	}
+	if time.Since(synced) > time.Minute {
+		t.Errorf("synced = %v, want about now", synced)
+	}
+}
+
+func TestFormatSyncAge(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[time.Duration]string{
+		5 * time.Second: "synced 5s ago",
+		3 * time.Minute: "synced 3m ago",
+		5 * time.Hour:   "synced 5h ago",
+		72 * time.Hour:  "synced 3d ago",
+	}
+	for age, want := range tests {
+		if got := formatSyncAge(now.Add(-age), now); got != want {
+			t.Errorf("formatSyncAge(%v) = %q, want %q", age, got, want)
+		}
+	}
+}
+
+func TestRemoteRefreshKeepsSelectionAndForm(t *testing.T) {
+	board := &Board{
+		Columns: []Column{{Name: "TODO"}, {Name: "DONE"}},
+		Cards: []*Card{
+			{ID: "1", Title: "First", Column: "TODO"},
+			{ID: "2", Title: "Second", Column: "TODO"},
+		},
+	}
+	board.PopulateColumnCards()
+	m := NewModelWithBackend(board, nil, nil)
+	m.lastSynced = time.Now().Add(-time.Minute)
+	m.refreshing = true
+	m.selectedCard = 1 // "Second"
+	m.formMode = FormEditCard
+	m.editingCardID = "2"
+
+	// A teammate adds a card above ours and renames ours
+	remote := &Board{
+		Columns: []Column{{Name: "TODO"}, {Name: "DONE"}},
+		Cards: []*Card{
+			{ID: "3", Title: "Teammate's", Column: "TODO"},
+			{ID: "1", Title: "First", Column: "TODO"},
+			{ID: "2", Title: "Second, renamed", Column: "TODO"},
+		},
+	}
+	remote.PopulateColumnCards()
+
+	updated, _ := m.Update(boardLoadedMsg{board: remote, started: time.Now().Add(-time.Second)})
+	m = updated.(Model)
+
+	if card := m.getCurrentCard(); card == nil || card.ID != "2" {
+		t.Errorf("selected card = %+v, want card 2", card)
+	}
+	if m.formMode != FormEditCard || m.editingCardID != "2" {
+		t.Errorf("form = %v editing %q, want the edit form left open", m.formMode, m.editingCardID)
+	}
+	if m.refreshing {
+		t.Error("refreshing should be cleared")
+	}
+	for _, id := range []string{"2", "3"} {
+		if !m.changedRemotely(m.board.FindCard(id)) {
+			t.Errorf("card %s should be highlighted", id)
+		}
+	}
+	if m.changedRemotely(m.board.FindCard("1")) {
+		t.Error("unchanged card 1 should not be highlighted")
+	}
+}
+
+func TestRefreshFromPreviousProjectIsDropped(t *testing.T) {
+	board := &Board{Columns: []Column{{Name: "TODO"}}, Cards: []*Card{{ID: "1", Title: "Local", Column: "TODO"}}}
+	board.PopulateColumnCards()
+	m := NewModelWithBackend(board, nil, NewLocalBackend(filepath.Join(t.TempDir(), ".tkan.yaml")))
+	m.lastSynced = time.Now()
+
+	// A GitHub refresh started before the user switched to this local board
+	remote := &Board{Columns: []Column{{Name: "TODO"}}, Cards: []*Card{{ID: "9", Title: "Remote", Column: "TODO"}}}
+	remote.PopulateColumnCards()
+	updated, _ := m.Update(boardLoadedMsg{backend: NewGitHubBackend("", "acme", 1, ""), board: remote, started: time.Now()})
+	m = updated.(Model)
+
+	if m.board.FindCard("9") != nil || m.board.FindCard("1") == nil {
+		t.Errorf("cards = %+v, want the local board untouched", m.board.Cards)
+	}
+}
+
+func TestResolveRefreshInterval(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	if got, err := ResolveRefreshInterval(""); err != nil || got != defaultRefreshInterval {
+		t.Errorf("default = %v, %v", got, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "tkan"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tkan", "config.yaml"), []byte("refresh_interval: 2m\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		flag string
+		want time.Duration
+		ok   bool
+	}{
+		{"", 2 * time.Minute, true},
+		{"30s", 30 * time.Second, true},
+		{"off", 0, true},
+		{"0", 0, true},
+		{"1s", 0, false},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ResolveRefreshInterval(tt.flag)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ResolveRefreshInterval(%q) = %v, %v", tt.flag, got, err)
+		}
+	}
+}
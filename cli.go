@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Subcommand is a non-interactive CLI command (e.g. `tkan due`)
+type Subcommand struct {
+	Summary string                    // One-line description for --help
+	Run     func(args []string) error // Runs the command with the remaining arguments
+}
+
+// subcommands lists the CLI commands available in addition to the TUI
+var subcommands = map[string]Subcommand{
+	"due":     {Summary: "List upcoming and overdue cards across all scanned projects", Run: runDueCommand},
+	"tick":    {Summary: "Create recurring cards that are due in all scanned projects", Run: runTickCommand},
+	"add":     {Summary: "Add a card: add [--column C] [--desc D] [--tags a,b] <title>", Run: runAddCommand},
+	"move":    {Summary: "Move a card to another column: move <id> <column>", Run: runMoveCommand},
+	"edit":    {Summary: "Edit card fields: edit <id> [--title T] [--desc D] [--due DATE] ...", Run: runEditCommand},
+	"convert": {Summary: "Convert a GitHub draft card to an issue: convert <id> --github owner/repo/N", Run: runConvertCommand},
+	"archive": {Summary: "Move a card to the ARCHIVE column: archive <id>", Run: runArchiveCommand},
+	"ls":      {Summary: "List cards: ls [--column C] [--tag T] [--all] [--json]", Run: runListCommand},
+	"show":    {Summary: "Show one card: show <id> [--json]", Run: runShowCommand},
+	"import":  {Summary: "Bulk add/update cards from CSV, Markdown or JSON: import [--format F] [file|-], or from issues: import github-issues owner/repo", Run: runImportCommand},
+	"export":  {Summary: "Export a board: export [--format csv|md|json|jira-json|jira-csv] [-o file]", Run: runExportCommand},
+	"sync":    {Summary: "Two-way sync a local board with a GitHub project: sync [--github owner/N] [--prefer local|remote]", Run: runSyncCommand},
+	"migrate": {Summary: "Copy a board between .tkan.yaml, .tkan.db (SQLite) and .tkan/ (a file per card): migrate [--to yaml|db|dir] [--force] <from> [to]", Run: runMigrateCommand},
+}
+
+// runSubcommand runs the named subcommand if it exists
+// Returns the process exit code and whether a subcommand matched
+func runSubcommand(name string, args []string) (int, bool) {
+	cmd, ok := subcommands[name]
+	if !ok {
+		return 0, false
+	}
+
+	if err := cmd.Run(args); err != nil {
+		fmt.Fprintf(os.Stderr, "tkan %s: %v\n", name, err)
+		return 1, true
+	}
+	return 0, true
+}
+
+// backendFlags are the flags shared by commands that operate on a single board
+type backendFlags struct {
+	board        *string
+	github       *string
+	githubHost   *string
+	githubIssues *bool
+	gitea        *string
+	gitlab       *string
+	gitlabDelete *bool
+}
+
+// addBackendFlags registers --board, --github, --gitea and --gitlab on fs
+func addBackendFlags(fs *flag.FlagSet) *backendFlags {
+	return &backendFlags{
+		board:  fs.String("board", ".tkan.yaml", "Path to a local board (.tkan.yaml, .tkan.db, a .tkan directory or TODO.md)"),
+		github: fs.String("github", "", "Use GitHub Project (owner/project-number or owner/repo/project-number)"),
+
+		githubHost:   fs.String("github-host", "", "GitHub host for --github (default github.com, or github_host in config.yaml)"),
+		githubIssues: fs.Bool("github-issues", false, "Create new GitHub cards as issues in the project's repository"),
+		gitea:        fs.String("gitea", "", "Use a Gitea/Forgejo project (host/owner/repo/project-id)"),
+		gitlab:       fs.String("gitlab", "", "Use a GitLab issue board (host/group/project/board-id)"),
+		gitlabDelete: fs.Bool("gitlab-delete-issues", false, "Delete GitLab issues when their cards are deleted, instead of closing them"),
+	}
+}
+
+// open creates the backend selected by the flags
+func (f *backendFlags) open() (Backend, error) {
+	remotes := 0
+	for _, spec := range []string{*f.github, *f.gitea, *f.gitlab} {
+		if spec != "" {
+			remotes++
+		}
+	}
+	if remotes > 1 {
+		return nil, fmt.Errorf("use only one of --github, --gitea and --gitlab")
+	}
+	if *f.gitlab != "" {
+		host, project, boardID, err := ParseGitLabBoardSpec(*f.gitlab)
+		if err != nil {
+			return nil, err
+		}
+		gl := NewGitLabBackend(host, project, boardID)
+		gl.deleteIssues = *f.gitlabDelete
+		return gl, nil
+	}
+	if *f.gitea != "" {
+		host, owner, repo, projectID, err := ParseGiteaProjectSpec(*f.gitea)
+		if err != nil {
+			return nil, err
+		}
+		return NewGiteaBackend(host, owner, repo, projectID), nil
+	}
+	if *f.github != "" {
+		owner, repoName, projectNum, err := ParseGitHubProjectSpec(*f.github)
+		if err != nil {
+			return nil, err
+		}
+		gh := NewGitHubBackend(ResolveGitHubHost(*f.githubHost, *f.github), owner, projectNum, repoName)
+		gh.createAsIssues = *f.githubIssues
+		return gh, nil
+	}
+
+	if _, err := os.Stat(*f.board); err != nil {
+		return nil, fmt.Errorf("board not found: %s", *f.board)
+	}
+	return NewFileBackend(*f.board), nil
+}
+
+// parseArgs parses flags that may appear before, between or after positional arguments
+// Returns the positional arguments in order ("--" ends flag parsing)
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			break
+		}
+
+		// Everything after "--" is positional
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+	return positional, nil
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// printSubcommandUsage prints the list of subcommands for --help
+func printSubcommandUsage() {
+	names := make([]string, 0, len(subcommands))
+	for name := range subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println("\nCommands:")
+	for _, name := range names {
+		fmt.Printf("  tkan %-20s # %s\n", name, subcommands[name].Summary)
+	}
+}
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// cardFlags are the card field flags shared by `tkan add` and `tkan edit`
+type cardFlags struct {
+	title    *string
+	desc     *string
+	tags     *string
+	assignee *string
+	due      *string
+	url      *string
+}
+
+// addCardFlags registers the card field flags on fs
+func addCardFlags(fs *flag.FlagSet) *cardFlags {
+	return &cardFlags{
+		title:    fs.String("title", "", "Card title"),
+		desc:     fs.String("desc", "", "Card description"),
+		tags:     fs.String("tags", "", "Comma-separated tags"),
+		assignee: fs.String("assignee", "", "Assignee (e.g. @alice)"),
+		due:      fs.String("due", "", "Due date (YYYY-MM-DD, empty to clear)"),
+		url:      fs.String("url", "", "Link to an issue, PR or other URL"),
+	}
+}
+
+// apply copies the flags that were set on the command line onto card
+func (f *cardFlags) apply(fs *flag.FlagSet, card *Card) error {
+	var err error
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "title":
+			card.Title = *f.title
+		case "desc":
+			card.Description = *f.desc
+		case "tags":
+			card.Tags = splitList(*f.tags)
+		case "assignee":
+			card.Assignee = *f.assignee
+		case "url":
+			card.URL = *f.url
+		case "due":
+			card.DueDate, err = NormalizeDueDate(*f.due)
+		}
+	})
+	return err
+}
+
+// loadCard loads the board from the backend and finds a card by ID
+func loadCard(backend Backend, id string) (*Board, *Card, error) {
+	board, err := backend.LoadBoard()
+	if err != nil {
+		return nil, nil, err
+	}
+	card := board.FindCard(id)
+	if card == nil {
+		return nil, nil, fmt.Errorf("card not found: %s", id)
+	}
+	return board, card, nil
+}
+
+// printCardJSON reloads a card from the backend and prints it as a card document
+// Reloading picks up server-assigned fields and computed fields like column index
+func printCardJSON(backend Backend, id string) error {
+	board, card, err := loadCard(backend, id)
+	if err != nil {
+		return err
+	}
+	return printJSON(CardDocumentJSON{
+		SchemaVersion: JSONSchemaVersion,
+		Card:          CardToJSON(board, card, time.Now()),
+	})
+}
+
+// runAddCommand creates a new card
+func runAddCommand(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	bf := addBackendFlags(fs)
+	cf := addCardFlags(fs)
+	column := fs.String("column", "", "Column to add the card to (default: first column)")
+	asJSON := fs.Bool("json", false, "Print the created card as JSON")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	title := strings.Join(positional, " ")
+	if *cf.title != "" {
+		title = *cf.title
+	}
+	if title == "" {
+		return fmt.Errorf("a title is required: tkan add [flags] <title>")
+	}
+	if _, err := NormalizeDueDate(*cf.due); err != nil {
+		return err
+	}
+
+	backend, err := bf.open()
+	if err != nil {
+		return err
+	}
+
+	board, err := backend.LoadBoard()
+	if err != nil {
+		return err
+	}
+	if *column == "" && len(board.Columns) > 0 {
+		*column = board.Columns[0].Name
+	}
+	colName, ok := board.ResolveColumn(*column)
+	if !ok {
+		return fmt.Errorf("unknown column: %s", *column)
+	}
+
+	card, err := backend.CreateCard(title, *cf.desc, colName)
+	if err != nil {
+		return err
+	}
+
+	// Set the remaining fields that CreateCard doesn't take
+	if *cf.tags != "" || *cf.assignee != "" || *cf.due != "" || *cf.url != "" {
+		if err := cf.apply(fs, card); err != nil {
+			return err
+		}
+		card.Title = title
+		if err := backend.UpdateCard(card); err != nil {
+			return err
+		}
+	}
+
+	if *asJSON {
+		return printCardJSON(backend, card.ID)
+	}
+	fmt.Printf("Created card %s in %s: %s\n", card.ID, card.Column, card.Title)
+	return nil
+}
+
+// runMoveCommand moves a card to another column
+func runMoveCommand(args []string) error {
+	fs := flag.NewFlagSet("move", flag.ContinueOnError)
+	bf := addBackendFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("usage: tkan move <id> <column>")
+	}
+
+	backend, err := bf.open()
+	if err != nil {
+		return err
+	}
+	board, card, err := loadCard(backend, positional[0])
+	if err != nil {
+		return err
+	}
+	colName, ok := board.ResolveColumn(positional[1])
+	if !ok {
+		return fmt.Errorf("unknown column: %s", positional[1])
+	}
+
+	if err := backend.MoveCard(card.ID, colName); err != nil {
+		return err
+	}
+	fmt.Printf("Moved card %s to %s\n", card.ID, colName)
+	return nil
+}
+
+// runEditCommand updates fields of an existing card
+func runEditCommand(args []string) error {
+	fs := flag.NewFlagSet("edit", flag.ContinueOnError)
+	bf := addBackendFlags(fs)
+	cf := addCardFlags(fs)
+	asJSON := fs.Bool("json", false, "Print the updated card as JSON")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tkan edit <id> [--title T] [--desc D] [--tags a,b] [--assignee A] [--due DATE] [--url URL]")
+	}
+
+	backend, err := bf.open()
+	if err != nil {
+		return err
+	}
+	_, card, err := loadCard(backend, positional[0])
+	if err != nil {
+		return err
+	}
+
+	if err := cf.apply(fs, card); err != nil {
+		return err
+	}
+	card.ModifiedAt = time.Now()
+
+	if err := backend.UpdateCard(card); err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printCardJSON(backend, card.ID)
+	}
+	fmt.Printf("Updated card %s: %s\n", card.ID, card.Title)
+	return nil
+}
+
+// runArchiveCommand moves a card to the ARCHIVE column
+func runArchiveCommand(args []string) error {
+	fs := flag.NewFlagSet("archive", flag.ContinueOnError)
+	bf := addBackendFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tkan archive <id>")
+	}
+
+	backend, err := bf.open()
+	if err != nil {
+		return err
+	}
+	board, card, err := loadCard(backend, positional[0])
+	if err != nil {
+		return err
+	}
+	archive, ok := board.ResolveColumn("ARCHIVE")
+	if !ok {
+		return fmt.Errorf("board has no ARCHIVE column")
+	}
+
+	if err := backend.MoveCard(card.ID, archive); err != nil {
+		return err
+	}
+	fmt.Printf("Archived card %s: %s\n", card.ID, card.Title)
+	return nil
+}
+
+// runListCommand lists the cards on a board
+func runListCommand(args []string) error {
+	fs := flag.NewFlagSet("ls", flag.ContinueOnError)
+	bf := addBackendFlags(fs)
+	column := fs.String("column", "", "Only list cards in this column")
+	all := fs.Bool("all", false, "Include archived cards")
+	tag := fs.String("tag", "", "Only list cards with this tag")
+	asJSON := fs.Bool("json", false, "Print the board and cards as JSON (see docs/reference/JSON_SCHEMA.md)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	backend, err := bf.open()
+	if err != nil {
+		return err
+	}
+	board, err := backend.LoadBoard()
+	if err != nil {
+		return err
+	}
+
+	query := CardQuery{Tag: *tag}
+	if *column != "" {
+		name, ok := board.ResolveColumn(*column)
+		if !ok {
+			return fmt.Errorf("unknown column: %s", *column)
+		}
+		query.Column = name
+	} else if !*all {
+		query.ExcludeColumns = []string{"ARCHIVE"}
+	}
+
+	cards, err := QueryCards(backend, board, query)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(BoardToJSON(board, cards, time.Now()))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCOLUMN\tDUE\tASSIGNEE\tTITLE")
+	for _, card := range cards {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", card.ID, card.Column, card.DueDate, card.Assignee, card.Title)
+	}
+	return w.Flush()
+}
+
+// runShowCommand prints the details of a single card
+func runShowCommand(args []string) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	bf := addBackendFlags(fs)
+	asJSON := fs.Bool("json", false, "Print the card as JSON")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tkan show <id> [--json]")
+	}
+
+	backend, err := bf.open()
+	if err != nil {
+		return err
+	}
+	board, card, err := loadCard(backend, positional[0])
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(CardDocumentJSON{
+			SchemaVersion: JSONSchemaVersion,
+			Card:          CardToJSON(board, card, time.Now()),
+		})
+	}
+
+	fmt.Printf("%s\n", card.Title)
+	fmt.Printf("  ID:       %s\n", card.ID)
+	fmt.Printf("  Column:   %s\n", card.Column)
+	if len(card.Tags) > 0 {
+		fmt.Printf("  Tags:     %s\n", strings.Join(card.Tags, ", "))
+	}
+	if card.Assignee != "" {
+		fmt.Printf("  Assignee: %s\n", card.Assignee)
+	}
+	if card.DueDate != "" {
+		fmt.Printf("  Due:      %s\n", card.DueDate)
+	}
+	if card.URL != "" {
+		fmt.Printf("  URL:      %s\n", card.URL)
+	}
+	fmt.Printf("  Created:  %s\n", card.CreatedAt.Format(time.RFC3339))
+	fmt.Printf("  Modified: %s\n", card.ModifiedAt.Format(time.RFC3339))
+	if card.Description != "" {
+		fmt.Printf("\n%s\n", card.Description)
+	}
+	return nil
+}
+
+// runConvertCommand converts a GitHub draft card to an issue in the project's repository
+func runConvertCommand(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	bf := addBackendFlags(fs)
+	asJSON := fs.Bool("json", false, "Print the converted card as JSON")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tkan convert <id> --github owner/repo/project-number")
+	}
+
+	backend, err := bf.open()
+	if err != nil {
+		return err
+	}
+	converter, ok := backend.(IssueConverter)
+	if !ok {
+		return fmt.Errorf("converting to an issue requires a GitHub project (--github owner/repo/project-number)")
+	}
+	_, card, err := loadCard(backend, positional[0])
+	if err != nil {
+		return err
+	}
+
+	if err := converter.ConvertToIssue(card); err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printCardJSON(backend, card.ID)
+	}
+	fmt.Printf("Converted card %s to issue %s\n", card.ID, card.URL)
+	return nil
+}
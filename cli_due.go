@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// dueEntry is a card with a due date and the project it belongs to
+type dueEntry struct {
+	Project string
+	Card    *Card
+	Due     time.Time
+	Status  DueStatus
+}
+
+// runDueCommand lists overdue and upcoming cards across all scanned projects
+func runDueCommand(args []string) error {
+	fs := flag.NewFlagSet("due", flag.ContinueOnError)
+	days := fs.Int("days", 7, "Show cards due within this many days")
+	all := fs.Bool("all", false, "Show all cards with a due date, however far out")
+	dir := fs.String("dir", ".", "Directory to scan for .tkan.yaml projects")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	projects, err := ScanProjects(*dir)
+	if err != nil {
+		return fmt.Errorf("failed to scan for projects: %w", err)
+	}
+
+	now := time.Now()
+	entries := collectDueEntries(projects, now, *days, *all)
+	if len(entries) == 0 {
+		if *all {
+			fmt.Println("No open cards have a due date.")
+		} else {
+			fmt.Printf("Nothing due in the next %d days.\n", *days)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DUE\tWHEN\tPROJECT\tCOLUMN\tASSIGNEE\tTITLE")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Due.Format(dueDateLayout),
+			formatDueRelative(e.Due, now),
+			e.Project,
+			e.Card.Column,
+			e.Card.Assignee,
+			e.Card.Title,
+		)
+	}
+	return w.Flush()
+}
+
+// collectDueEntries gathers overdue and upcoming cards from the given projects
+// sorted by due date. Cards in DONE and ARCHIVE are skipped.
+func collectDueEntries(projects []Project, now time.Time, days int, all bool) []dueEntry {
+	var entries []dueEntry
+
+	query := CardQuery{ExcludeColumns: []string{"DONE", "ARCHIVE"}, HasDueDate: true}
+	if !all {
+		query.DueBy = now.AddDate(0, 0, days).Format(dueDateLayout)
+	}
+
+	for _, project := range projects {
+		backend := NewFileBackend(project.Path)
+		cards, err := QueryCards(backend, nil, query)
+		if closer, ok := backend.(io.Closer); ok {
+			closer.Close()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", project.Path, err)
+			continue
+		}
+
+		for _, card := range cards {
+			due, ok := card.Due()
+			if !ok {
+				continue
+			}
+			status := card.DueStatusAt(now, days)
+			if status == DueNone || (status == DueLater && !all) {
+				continue
+			}
+			entries = append(entries, dueEntry{
+				Project: project.Name,
+				Card:    card,
+				Due:     due,
+				Status:  status,
+			})
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b dueEntry) int {
+		return a.Due.Compare(b.Due)
+	})
+	return entries
+}
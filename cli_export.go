@@ -0,0 +1,138 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// runExportCommand writes a board as CSV, Markdown, JSON or a Jira export
+func runExportCommand(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	bf := addBackendFlags(fs)
+	format := fs.String("format", "", "Output format: csv, md, json, jira-json or jira-csv (default: from -o extension, else json)")
+	jiraMapping := fs.String("jira-mapping", "", "Jira status mapping file for jira-* formats (default: ~/.config/tkan/jira.yaml)")
+	output := fs.String("o", "", "Output file (default: stdout)")
+	all := fs.Bool("all", false, "Include archived cards")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	fmtName, err := resolveFormat(*format, *output)
+	if err != nil {
+		return err
+	}
+	var mapping *JiraMapping
+	if isJiraFormat(fmtName) {
+		if mapping, err = LoadJiraMapping(*jiraMapping); err != nil {
+			return err
+		}
+	}
+
+	backend, err := bf.open()
+	if err != nil {
+		return err
+	}
+	board, err := backend.LoadBoard()
+	if err != nil {
+		return err
+	}
+	if !*all {
+		board = boardWithCards(board, withoutArchived(board.Cards))
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "" && *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if mapping != nil {
+		return ExportJira(out, board, fmtName, mapping)
+	}
+	return ExportBoard(out, board, fmtName)
+}
+
+// resolveFormat picks the format from the flag, falling back to the file extension, then JSON
+func resolveFormat(flagValue, path string) (string, error) {
+	if flagValue != "" {
+		return normalizeFormat(flagValue)
+	}
+	if format, ok := formatFromPath(path); ok {
+		return format, nil
+	}
+	return FormatJSON, nil
+}
+
+// withoutArchived returns the cards that aren't in the ARCHIVE column
+func withoutArchived(cards []*Card) []*Card {
+	var kept []*Card
+	for _, card := range cards {
+		if card.Column != "ARCHIVE" {
+			kept = append(kept, card)
+		}
+	}
+	return kept
+}
+
+// boardWithCards returns a shallow copy of board containing only the given cards
+func boardWithCards(board *Board, cards []*Card) *Board {
+	subset := *board
+	subset.Columns = append([]Column(nil), board.Columns...)
+	subset.Cards = cards
+	subset.PopulateColumnCards()
+	return &subset
+}
+
+// createImportedBoard writes an imported board to a new local board, in the format
+// its path selects (see NewFileBackend)
+func createImportedBoard(path string, board *Board) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("board already exists: %s", path)
+	}
+
+	now := time.Now()
+	if board.Name == "" {
+		board.Name = "Imported Board"
+	}
+	if board.CreatedAt.IsZero() {
+		board.CreatedAt = now
+	}
+	if len(board.Columns) == 0 {
+		board.Columns = CreateDefaultBoard().Columns
+		for i := range board.Columns {
+			board.Columns[i].Cards = nil
+		}
+	}
+
+	cards := board.Cards
+	board.Cards = nil
+	for _, card := range cards {
+		if err := normalizeImportedCard(board, card); err != nil {
+			return fmt.Errorf("card %q: %w", card.Title, err)
+		}
+		if card.ID == "" || board.FindCard(card.ID) != nil {
+			card.ID = board.NextCardID()
+		}
+		if card.CreatedAt.IsZero() {
+			card.CreatedAt = now
+		}
+		if card.ModifiedAt.IsZero() {
+			card.ModifiedAt = now
+		}
+		board.Cards = append(board.Cards, card)
+	}
+	board.PopulateColumnCards()
+
+	backend := NewFileBackend(path)
+	if closer, ok := backend.(io.Closer); ok {
+		defer closer.Close()
+	}
+	return backend.SaveBoard(board)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestCreateImportedBoardFormats(t *testing.T) {
+	for _, name := range []string{".tkan.yaml", ".tkan.db", "TODO.md", ".tkan"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			imported := &Board{
+				Name: "Imported",
+				Cards: []*Card{
+					{Title: "Fix login", Description: "Steps", Tags: []string{"bug"}, Column: "todo"},
+					{Title: "Ship", Column: "DONE"},
+				},
+			}
+			if err := createImportedBoard(path, imported); err != nil {
+				t.Fatal(err)
+			}
+			if err := createImportedBoard(path, &Board{}); err == nil {
+				t.Error("importing onto an existing board should fail")
+			}
+
+			backend := NewFileBackend(path)
+			if closer, ok := backend.(io.Closer); ok {
+				defer closer.Close()
+			}
+			board, err := backend.LoadBoard()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var cards []string
+			for _, card := range board.Cards {
+				cards = append(cards, card.Title+":"+card.Column)
+			}
+			if board.Name != "Imported" || !slices.Equal(cards, []string{"Fix login:TODO", "Ship:DONE"}) {
+				t.Errorf("board %q cards = %v", board.Name, cards)
+			}
+			if card := board.Cards[0]; card.Description != "Steps" || !slices.Equal(card.Tags, []string{"bug"}) {
+				t.Errorf("card = %+v", card)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// importResult counts what an import did
+type importResult struct {
+	Created int
+	Updated int
+}
+
+// runImportCommand bulk-imports cards into a board (or GitHub issues, see runImportGitHubIssuesCommand)
+// Cards with an ID (or URL) that exists on the board are updated, all others are created
+func runImportCommand(args []string) error {
+	if len(args) > 0 && args[0] == "github-issues" {
+		return runImportGitHubIssuesCommand(args[1:])
+	}
+
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	bf := addBackendFlags(fs)
+	format := fs.String("format", "", "Input format: csv, md, json, jira-json or jira-csv (default: from file extension, else json)")
+	jiraMapping := fs.String("jira-mapping", "", "Jira status mapping file for jira-* formats (default: ~/.config/tkan/jira.yaml)")
+	create := fs.Bool("create", false, "Create the --board file from the import if it doesn't exist")
+	dryRun := fs.Bool("dry-run", false, "Validate and report without changing the board")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf("usage: tkan import [--format csv|md|json] [file|-] (reads stdin by default)")
+	}
+
+	var in io.Reader = os.Stdin
+	path := ""
+	if len(positional) == 1 && positional[0] != "-" {
+		path = positional[0]
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	fmtName, err := resolveFormat(*format, path)
+	if err != nil {
+		return err
+	}
+	var imported *Board
+	if isJiraFormat(fmtName) {
+		mapping, mapErr := LoadJiraMapping(*jiraMapping)
+		if mapErr != nil {
+			return mapErr
+		}
+		imported, err = ImportJira(in, fmtName, mapping)
+	} else {
+		imported, err = ImportBoard(in, fmtName)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Start a new local board from the import
+	if *create && *bf.github == "" && *bf.gitea == "" && *bf.gitlab == "" {
+		if _, statErr := os.Stat(*bf.board); os.IsNotExist(statErr) {
+			if *dryRun {
+				fmt.Printf("Would create %s with %d cards\n", *bf.board, len(imported.Cards))
+				return nil
+			}
+			if err := createImportedBoard(*bf.board, imported); err != nil {
+				return err
+			}
+			fmt.Printf("Created %s with %d cards\n", *bf.board, len(imported.Cards))
+			return nil
+		}
+	}
+
+	backend, err := bf.open()
+	if err != nil {
+		return err
+	}
+
+	result, err := importCards(backend, imported.Cards, *dryRun)
+	verb := "Imported"
+	if *dryRun {
+		verb = "Would import"
+	}
+	fmt.Printf("%s %d cards (%d created, %d updated)\n", verb, result.Created+result.Updated, result.Created, result.Updated)
+	return err
+}
+
+// importCards upserts cards into the backend's board
+// Invalid cards are reported in the returned error and skipped
+func importCards(backend Backend, cards []*Card, dryRun bool) (importResult, error) {
+	var result importResult
+	var errs []error
+
+	board, err := backend.LoadBoard()
+	if err != nil {
+		return result, err
+	}
+
+	for i, card := range cards {
+		if err := normalizeImportedCard(board, card); err != nil {
+			errs = append(errs, fmt.Errorf("card %d (%q): %w", i+1, card.Title, err))
+			continue
+		}
+
+		existing := findImportedCard(board, card)
+		if existing != nil {
+			card.ID = existing.ID
+			if !dryRun {
+				if err := updateImportedCard(backend, existing, card); err != nil {
+					errs = append(errs, fmt.Errorf("card %s: %w", card.ID, err))
+					continue
+				}
+			}
+			result.Updated++
+			continue
+		}
+
+		if !dryRun {
+			if err := createImportedCard(backend, card); err != nil {
+				errs = append(errs, fmt.Errorf("card %d (%q): %w", i+1, card.Title, err))
+				continue
+			}
+		}
+		result.Created++
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// findImportedCard returns the board card an imported card updates: the card with
+// the same ID, else the card linking to the same URL (e.g. a Jira issue)
+func findImportedCard(board *Board, card *Card) *Card {
+	if card.ID != "" {
+		if existing := board.FindCard(card.ID); existing != nil {
+			return existing
+		}
+	}
+	if card.URL != "" {
+		for _, existing := range board.Cards {
+			if existing.URL == card.URL {
+				return existing
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeImportedCard validates a card and resolves its column against the board
+func normalizeImportedCard(board *Board, card *Card) error {
+	if card.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+
+	if card.Column == "" && len(board.Columns) > 0 {
+		card.Column = board.Columns[0].Name
+	}
+	colName, ok := board.ResolveColumn(card.Column)
+	if !ok {
+		return fmt.Errorf("unknown column: %s", card.Column)
+	}
+	card.Column = colName
+
+	due, err := NormalizeDueDate(card.DueDate)
+	if err != nil {
+		return err
+	}
+	card.DueDate = due
+	return nil
+}
+
+// updateImportedCard overwrites an existing card with imported fields
+// The card keeps its pairing with a GitHub project item, which imports don't carry
+func updateImportedCard(backend Backend, existing, card *Card) error {
+	fromColumn := existing.Column
+
+	card.RemoteID = existing.RemoteID
+	card.ContentType = existing.ContentType
+	card.ContentID = existing.ContentID
+
+	if card.CreatedAt.IsZero() {
+		card.CreatedAt = existing.CreatedAt
+	}
+	card.ModifiedAt = time.Now()
+
+	if err := backend.UpdateCard(card); err != nil {
+		return err
+	}
+	if card.Column != fromColumn {
+		return backend.MoveCard(card.ID, card.Column)
+	}
+	return nil
+}
+
+// createImportedCard creates a new card and sets the fields CreateCard doesn't take
+func createImportedCard(backend Backend, card *Card) error {
+	created, err := backend.CreateCard(card.Title, card.Description, card.Column)
+	if err != nil {
+		return err
+	}
+
+	if len(card.Tags) == 0 && card.Assignee == "" && card.DueDate == "" && card.URL == "" {
+		return nil
+	}
+
+	created.Tags = card.Tags
+	created.Assignee = card.Assignee
+	created.DueDate = card.DueDate
+	created.URL = card.URL
+	return backend.UpdateCard(created)
+}
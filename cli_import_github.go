@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// runImportGitHubIssuesCommand implements `tkan import github-issues owner/repo`
+// Re-running it updates the cards of issues imported before instead of adding duplicates
+func runImportGitHubIssuesCommand(args []string) error {
+	fs := flag.NewFlagSet("import github-issues", flag.ContinueOnError)
+	bf := addBackendFlags(fs)
+	state := fs.String("state", "open", "Issues to import: open, closed or all")
+	labels := fs.String("label", "", "Only import issues with all of these labels (comma-separated)")
+	milestone := fs.String("milestone", "", "Only import issues in this milestone (title or number)")
+	columns := fs.String("column", "", "Put open issues with a label in a column: label=COLUMN[,label=COLUMN...] (first match wins)")
+	openColumn := fs.String("open-column", "", "Column for other open issues (default: the board's first column)")
+	closedColumn := fs.String("closed-column", "", "Column for closed issues (default: DONE)")
+	dryRun := fs.Bool("dry-run", false, "Report what would change without changing the board")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: tkan import github-issues owner/repo [--state open|closed|all] [--label L,...] [--milestone M] [--column label=COLUMN,...]")
+	}
+
+	owner, repo, ok := strings.Cut(positional[0], "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return fmt.Errorf("invalid repository %q (want owner/repo)", positional[0])
+	}
+
+	filter := GitHubIssueFilter{State: *state, Milestone: *milestone}
+	for _, label := range strings.Split(*labels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			filter.Labels = append(filter.Labels, label)
+		}
+	}
+	rules := IssueColumnRules{Open: *openColumn, Closed: *closedColumn}
+	if rules.Labels, err = ParseIssueLabelRules(*columns); err != nil {
+		return err
+	}
+
+	backend, err := bf.open()
+	if err != nil {
+		return err
+	}
+
+	host := ResolveGitHubHost(*bf.githubHost, positional[0])
+	gql := NewGitHubScheduler(NewGraphQLClient(host))
+	issues, err := ListGitHubIssues(gql, owner, repo, filter)
+	if err != nil {
+		return fmt.Errorf("failed to list issues of %s: %w", positional[0], err)
+	}
+
+	// Open issues imported before may have been closed since; look those up so their cards move
+	if state := strings.ToLower(*state); state == "" || state == "open" {
+		board, err := backend.LoadBoard()
+		if err != nil {
+			return err
+		}
+		closed, err := ListClosedGitHubIssues(gql, owner, repo, OpenImportedIssues(board, rules, owner, repo, issues))
+		if err != nil {
+			return fmt.Errorf("failed to check imported issues of %s: %w", positional[0], err)
+		}
+		issues = append(issues, closed...)
+	}
+
+	result, err := ImportGitHubIssues(backend, issues, rules, *dryRun)
+	verb := "Imported"
+	if *dryRun {
+		verb = "Would import"
+	}
+	fmt.Printf("Found %d issues. %s %d (%d created, %d updated)\n", len(issues), verb, result.Created+result.Updated, result.Created, result.Updated)
+	return err
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// runMigrateCommand implements `tkan migrate`: copies a board to another storage format
+func runMigrateCommand(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	format := fs.String("to", "", "Format of the default destination: yaml, db (SQLite) or dir (a file per card)")
+	force := fs.Bool("force", false, "Overwrite the destination if it exists")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 || len(positional) > 2 {
+		return fmt.Errorf("usage: tkan migrate [--to yaml|db|dir] [--force] <from> [to]  (e.g. .tkan.yaml .tkan.db)")
+	}
+
+	from := filepath.Clean(positional[0])
+	to := ""
+	if len(positional) == 2 {
+		to = positional[1]
+	} else if to, err = migrateDestination(from, *format); err != nil {
+		return err
+	}
+
+	board, err := MigrateBoard(from, to, *force)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Copied %d cards from %s to %s\n", len(board.Cards), from, to)
+	fmt.Printf("%s was left in place; remove it once you've switched, or tkan will list both boards\n", from)
+	return nil
+}
+
+// migrateDestination returns the default target of `tkan migrate from`: .tkan.yaml,
+// .tkan.db or .tkan/ next to from (keeping a name.tkan prefix). Without a format,
+// YAML and Markdown boards go to SQLite and the others to YAML.
+func migrateDestination(from, format string) (string, error) {
+	base := strings.TrimSuffix(from, filepath.Ext(from))
+	if !strings.HasSuffix(filepath.Base(base), ".tkan") {
+		base = filepath.Join(filepath.Dir(from), ".tkan")
+	}
+
+	if format == "" {
+		format = "db"
+		if isSQLiteBoard(from) || isDirectoryBoard(from) {
+			format = "yaml"
+		}
+	}
+	switch format {
+	case "yaml":
+		return base + ".yaml", nil
+	case "db", "sqlite":
+		return base + ".db", nil
+	case "dir":
+		return base, nil
+	}
+	return "", fmt.Errorf("--to must be yaml, db or dir, not %q", format)
+}
+
+// MigrateBoard copies the board at from to a new board at to, in the format its
+// path selects (see NewFileBackend). The copy is written next to to and renamed into place,
+// so an existing destination (with force) is only replaced once the copy succeeded.
+func MigrateBoard(from, to string, force bool) (*Board, error) {
+	if filepath.Clean(from) == filepath.Clean(to) {
+		return nil, fmt.Errorf("source and destination are the same file: %s", from)
+	}
+	if isMarkdownBoard(to) {
+		return nil, fmt.Errorf("can't migrate to Markdown; use tkan export --format md")
+	}
+	if info, err := os.Stat(to); err == nil {
+		if !force {
+			return nil, fmt.Errorf("%s already exists (use --force to overwrite it)", to)
+		}
+		// --force replaces boards, not other directories that happen to be in the way
+		if _, err := os.Stat(filepath.Join(to, "board.yaml")); info.IsDir() && err != nil {
+			return nil, fmt.Errorf("%s is a directory that isn't a board; not replacing it", to)
+		}
+	}
+
+	source := NewFileBackend(from)
+	board, err := source.LoadBoard()
+	if closer, ok := source.(io.Closer); ok {
+		closer.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The prefix keeps the extension, which selects the format
+	tmp := filepath.Join(filepath.Dir(to), "tkan-migrate-"+filepath.Base(to))
+	os.RemoveAll(tmp)
+	dest := NewFileBackend(tmp)
+	err = dest.SaveBoard(board)
+	if closer, ok := dest.(io.Closer); ok {
+		closer.Close()
+	}
+	if err != nil {
+		os.RemoveAll(tmp)
+		return nil, err
+	}
+
+	// A directory can't be renamed over, so a board directory being replaced goes first
+	if info, err := os.Stat(to); err == nil && info.IsDir() {
+		if err := os.RemoveAll(to); err != nil {
+			os.RemoveAll(tmp)
+			return nil, fmt.Errorf("failed to replace %s: %w", to, err)
+		}
+	}
+	if err := os.Rename(tmp, to); err != nil {
+		os.RemoveAll(tmp)
+		return nil, fmt.Errorf("failed to write %s: %w", to, err)
+	}
+	return board, nil
+}
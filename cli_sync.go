@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+// runSyncCommand implements `tkan sync`: two-way sync of a local board with a GitHub project
+func runSyncCommand(args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	board := fs.String("board", ".tkan.yaml", "Path to the local board (.tkan.yaml, .tkan.db or .tkan/ directory)")
+	github := fs.String("github", "", "GitHub Project to link the board to (owner/project-number or owner/repo/project-number); only needed the first time")
+	githubHost := fs.String("github-host", "", "GitHub host for --github (default github.com, or github_host in config.yaml)")
+	prefer := fs.String("prefer", "", "Resolve conflicts by keeping the local or remote version: local|remote")
+	dryRun := fs.Bool("dry-run", false, "Show what would change without changing anything")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return fmt.Errorf("usage: tkan sync [--board FILE] [--github owner/N] [--prefer local|remote] [--dry-run]")
+	}
+
+	var resolution ConflictResolution
+	switch *prefer {
+	case "":
+	case "local":
+		resolution = ResolveKeepLocal
+	case "remote":
+		resolution = ResolveKeepRemote
+	default:
+		return fmt.Errorf("--prefer must be local or remote, not %q", *prefer)
+	}
+
+	session, err := StartSync(*board, *github, *githubHost)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	plan := session.Plan
+	if resolution != ResolveUnset {
+		plan.ResolveAll(resolution)
+	}
+
+	if *dryRun {
+		printSyncPlan(plan)
+		return nil
+	}
+	if n := plan.Unresolved(); n > 0 {
+		printSyncPlan(plan)
+		return fmt.Errorf("%d conflict(s): rerun with --prefer local|remote, or open the board in tkan and press S to resolve them", n)
+	}
+
+	result, err := session.Apply()
+	if err != nil {
+		return err
+	}
+	for _, e := range result.Errors {
+		fmt.Printf("  failed: %v\n", e)
+	}
+	fmt.Printf("Synced %s with %s: %s\n", session.Path, session.Local.Sync.Remote, result)
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d change(s) failed; they will be retried by the next sync", len(result.Errors))
+	}
+	return nil
+}
+
+// printSyncPlan lists the actions and conflicts in a sync plan
+func printSyncPlan(plan *SyncPlan) {
+	if len(plan.Actions) == 0 && len(plan.Conflicts) == 0 {
+		fmt.Println("Already in sync")
+		return
+	}
+	for _, action := range plan.Actions {
+		fmt.Printf("  %-14s %s\n", action.Kind, syncActionTitle(action))
+	}
+	for _, c := range plan.Conflicts {
+		state := "unresolved"
+		switch c.Resolution {
+		case ResolveKeepLocal:
+			state = "keep local"
+		case ResolveKeepRemote:
+			state = "keep remote"
+		}
+		fmt.Printf("  %-14s %s (%s)\n", "conflict", c.Title(), state)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// runTickCommand generates due recurring cards in every scanned project
+func runTickCommand(args []string) error {
+	fs := flag.NewFlagSet("tick", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "Directory to scan for .tkan.yaml projects")
+	dryRun := fs.Bool("dry-run", false, "Show which cards would be created without saving")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	projects, err := ScanProjects(*dir)
+	if err != nil {
+		return fmt.Errorf("failed to scan for projects: %w", err)
+	}
+
+	now := time.Now()
+	total := 0
+	for _, project := range projects {
+		backend := NewFileBackend(project.Path)
+		board, err := backend.LoadBoard()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", project.Path, err)
+			continue
+		}
+
+		var created []*Card
+		if *dryRun {
+			created, err = ApplyRecurrence(board, AvailableTemplates(board), now)
+		} else {
+			created, err = tickBoard(backend, board, now)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", project.Name, err)
+		}
+
+		for _, card := range created {
+			fmt.Printf("%s: created %q in %s\n", project.Name, card.Title, card.Column)
+		}
+		total += len(created)
+	}
+
+	if total == 0 {
+		fmt.Println("No recurring cards due.")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configDir returns tkan's user configuration directory (~/.config/tkan)
+// $XDG_CONFIG_HOME is honored when set
+func configDir() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "tkan"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "tkan"), nil
+}
+
+// defaultGitHubHost is used when no GitHub host is configured
+const defaultGitHubHost = "github.com"
+
+// Config is the user configuration in ~/.config/tkan/config.yaml
+type Config struct {
+	GitHubHost      string                 `yaml:"github_host,omitempty"`      // Default GitHub host (e.g. github.example.com)
+	RefreshInterval string                 `yaml:"refresh_interval,omitempty"` // How often remote boards are re-fetched (e.g. "30s", "0" to disable)
+	Boards          map[string]BoardConfig `yaml:"boards,omitempty"`           // Per-board settings keyed by GitHub project spec
+	GiteaTokens     map[string]string      `yaml:"gitea_tokens,omitempty"`     // Gitea/Forgejo access tokens keyed by host
+	GitLabTokens    map[string]string      `yaml:"gitlab_tokens,omitempty"`    // GitLab access tokens keyed by host
+}
+
+// BoardConfig holds settings for one GitHub board ("owner/N" or "owner/repo/N")
+type BoardConfig struct {
+	GitHubHost string `yaml:"github_host,omitempty"`
+}
+
+// LoadConfig reads the user configuration, returning an empty config if there is none
+func LoadConfig() (*Config, error) {
+	cfg := &Config{}
+
+	dir, err := configDir()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config.yaml: %w", err)
+	}
+	return cfg, nil
+}
+
+// ResolveGitHubHost picks the GitHub host for a project spec
+// Precedence: flagHost, the board's config entry, $GH_HOST, the config default, github.com
+func ResolveGitHubHost(flagHost, spec string) string {
+	if flagHost != "" {
+		return normalizeGitHubHost(flagHost)
+	}
+
+	cfg, _ := LoadConfig()
+	if board, ok := cfg.Boards[spec]; ok && board.GitHubHost != "" {
+		return normalizeGitHubHost(board.GitHubHost)
+	}
+	if host := os.Getenv("GH_HOST"); host != "" {
+		return normalizeGitHubHost(host)
+	}
+	if cfg.GitHubHost != "" {
+		return normalizeGitHubHost(cfg.GitHubHost)
+	}
+	return defaultGitHubHost
+}
+
+// defaultRefreshInterval is how often remote boards are re-fetched when not configured
+const defaultRefreshInterval = time.Minute
+
+// ResolveRefreshInterval picks the polling interval for remote boards
+// Precedence: flagValue, refresh_interval in the config, defaultRefreshInterval
+// "0" or "off" disables polling
+func ResolveRefreshInterval(flagValue string) (time.Duration, error) {
+	value := flagValue
+	if value == "" {
+		cfg, _ := LoadConfig()
+		value = cfg.RefreshInterval
+	}
+	if value == "" {
+		return defaultRefreshInterval, nil
+	}
+	if value == "0" || strings.EqualFold(value, "off") {
+		return 0, nil
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid refresh interval %q (use e.g. 30s or 5m)", value)
+	}
+	if interval < 5*time.Second {
+		return 0, fmt.Errorf("refresh interval %s is too short (minimum 5s)", interval)
+	}
+	return interval, nil
+}
+
+// normalizeGitHubHost strips a scheme and trailing slash from a host ("https://ghe.corp/" -> "ghe.corp")
+func normalizeGitHubHost(host string) string {
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	return strings.ToLower(strings.TrimRight(host, "/"))
+}
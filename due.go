@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultDueSoonDays is how many days ahead a due date counts as "due soon"
+const defaultDueSoonDays = 3
+
+// dueDateLayout is the canonical format for Card.DueDate
+const dueDateLayout = "2006-01-02"
+
+// dueDateLayouts are the formats accepted when parsing Card.DueDate
+var dueDateLayouts = []string{
+	dueDateLayout,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"2 Jan 2006",
+}
+
+// DueStatus classifies a card's due date relative to today
+type DueStatus int
+
+const (
+	DueNone    DueStatus = iota // No due date, unparseable, or card is finished
+	DueLater                    // Due date is further out than the "soon" window
+	DueSoon                     // Due within the "soon" window (including today)
+	DueOverdue                  // Due date has passed
+)
+
+// String returns a short label for the due status
+func (s DueStatus) String() string {
+	switch s {
+	case DueLater:
+		return "later"
+	case DueSoon:
+		return "soon"
+	case DueOverdue:
+		return "overdue"
+	default:
+		return "none"
+	}
+}
+
+// ParseDueDate parses a due date string in any of the accepted layouts
+// The result is truncated to midnight local time so comparisons are per-day
+func ParseDueDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty due date")
+	}
+
+	for _, layout := range dueDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return startOfDay(t), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid due date %q (use YYYY-MM-DD)", s)
+}
+
+// NormalizeDueDate validates a due date and returns it in the canonical layout
+// An empty string is valid and clears the due date
+func NormalizeDueDate(s string) (string, error) {
+	if strings.TrimSpace(s) == "" {
+		return "", nil
+	}
+	t, err := ParseDueDate(s)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(dueDateLayout), nil
+}
+
+// startOfDay returns midnight of the given day in local time
+func startOfDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// Due returns the parsed due date of the card, if it has a valid one
+func (c *Card) Due() (time.Time, bool) {
+	if c.DueDate == "" {
+		return time.Time{}, false
+	}
+	t, err := ParseDueDate(c.DueDate)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// DueStatusAt classifies the card's due date as of now
+// Cards in DONE or ARCHIVE are never overdue or due soon
+func (c *Card) DueStatusAt(now time.Time, soonDays int) DueStatus {
+	if c.Column == "DONE" || c.Column == "ARCHIVE" {
+		return DueNone
+	}
+
+	due, ok := c.Due()
+	if !ok {
+		return DueNone
+	}
+
+	days := daysUntil(due, now)
+	switch {
+	case days < 0:
+		return DueOverdue
+	case days <= soonDays:
+		return DueSoon
+	default:
+		return DueLater
+	}
+}
+
+// daysUntil returns the number of calendar days from now until due (negative if past)
+// The local dates are compared as UTC days, so a DST change in between doesn't shorten a day
+func daysUntil(due, now time.Time) int {
+	due, now = due.In(time.Local), now.In(time.Local)
+	a := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return int(a.Sub(b).Hours() / 24)
+}
+
+// formatDueRelative describes a due date relative to now (e.g. "in 2d", "3d overdue")
+func formatDueRelative(due, now time.Time) string {
+	days := daysUntil(due, now)
+	switch {
+	case days < 0:
+		return fmt.Sprintf("%dd overdue", -days)
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	default:
+		return fmt.Sprintf("in %dd", days)
+	}
+}
+
+// formatDueCell formats a card's due date for the table view
+func formatDueCell(card *Card, now time.Time, soonDays int) string {
+	due, ok := card.Due()
+	if !ok {
+		return card.DueDate
+	}
+
+	switch card.DueStatusAt(now, soonDays) {
+	case DueOverdue:
+		return fmt.Sprintf("%s (overdue)", due.Format(dueDateLayout))
+	case DueSoon:
+		return fmt.Sprintf("%s (%s)", due.Format(dueDateLayout), formatDueRelative(due, now))
+	default:
+		return due.Format(dueDateLayout)
+	}
+}
+
+// compareDue orders two cards by due date; cards without a due date sort last
+func compareDue(a, b *Card) int {
+	da, okA := a.Due()
+	db, okB := b.Due()
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return 1
+	case !okB:
+		return -1
+	default:
+		return da.Compare(db)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// withLocalZone runs the test with time.Local set to the named zone
+func withLocalZone(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+	saved := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = saved })
+	return loc
+}
+
+func TestDaysUntilAcrossDST(t *testing.T) {
+	loc := withLocalZone(t, "America/New_York")
+
+	tests := []struct {
+		now  time.Time
+		due  string
+		want int
+	}{
+		// Clocks go forward on 2026-03-08, so that day is 23 hours long
+		{time.Date(2026, 3, 7, 23, 30, 0, 0, loc), "2026-03-09", 2},
+		{time.Date(2026, 3, 8, 0, 30, 0, 0, loc), "2026-03-08", 0},
+		{time.Date(2026, 3, 9, 12, 0, 0, 0, loc), "2026-03-07", -2},
+		// And back on 2026-11-01, a 25-hour day
+		{time.Date(2026, 10, 31, 8, 0, 0, 0, loc), "2026-11-02", 2},
+		{time.Date(2026, 11, 2, 23, 59, 0, 0, loc), "2026-10-31", -2},
+	}
+	for _, tt := range tests {
+		due, err := ParseDueDate(tt.due)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := daysUntil(due, tt.now); got != tt.want {
+			t.Errorf("daysUntil(%s, %s) = %d, want %d", tt.due, tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestDueStatusBoundaries(t *testing.T) {
+	withLocalZone(t, "America/New_York")
+	now := time.Date(2026, 3, 6, 23, 59, 0, 0, time.Local)
+
+	tests := []struct {
+		due    string
+		column string
+		want   DueStatus
+	}{
+		{"2026-03-05", "TODO", DueOverdue},
+		{"2026-03-06", "TODO", DueSoon}, // Today
+		{"2026-03-09", "TODO", DueSoon}, // The last day of the window, across the DST change
+		{"2026-03-10", "TODO", DueLater},
+		{"2026-03-05", "DONE", DueNone},
+		{"2026-03-05", "ARCHIVE", DueNone},
+		{"someday", "TODO", DueNone},
+		{"", "TODO", DueNone},
+	}
+	for _, tt := range tests {
+		card := &Card{DueDate: tt.due, Column: tt.column}
+		if got := card.DueStatusAt(now, defaultDueSoonDays); got != tt.want {
+			t.Errorf("%s in %s: status = %v, want %v", tt.due, tt.column, got, tt.want)
+		}
+	}
+
+	if got := formatDueRelative(time.Date(2026, 3, 7, 0, 0, 0, 0, time.Local), now); got != "tomorrow" {
+		t.Errorf("formatDueRelative = %q, want tomorrow", got)
+	}
+}
+
+func TestCardQueryDueByParsesDates(t *testing.T) {
+	query := CardQuery{DueBy: "2026-03-06"}
+
+	tests := []struct {
+		due  string
+		want bool
+	}{
+		{"2026-03-05", true},
+		{"2026-03-06", true},
+		{"2026-03-07", false},
+		{"Jan 2, 2020", true}, // Sorts after "2026-..." as a string
+		{"Dec 31, 2026", false},
+		{"soon", false},
+	}
+	for _, tt := range tests {
+		card := &Card{DueDate: tt.due}
+		if got := query.Matches(card); got != tt.want {
+			t.Errorf("due %q: Matches = %v, want %v", tt.due, got, tt.want)
+		}
+	}
+}
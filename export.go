@@ -0,0 +1,452 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Export/import formats
+const (
+	FormatCSV      = "csv"
+	FormatMarkdown = "md"
+	FormatJSON     = "json"
+)
+
+// exportFormats lists the supported formats in the order shown in the TUI
+var exportFormats = []string{FormatCSV, FormatMarkdown, FormatJSON}
+
+// formatExtensions maps each format to its file extension
+var formatExtensions = map[string]string{
+	FormatCSV:      ".csv",
+	FormatMarkdown: ".md",
+	FormatJSON:     ".json",
+}
+
+// formatFromPath infers an export/import format from a file extension
+func formatFromPath(path string) (string, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, true
+	case ".md", ".markdown":
+		return FormatMarkdown, true
+	case ".json":
+		return FormatJSON, true
+	}
+	return "", false
+}
+
+// normalizeFormat validates a format name (accepting "markdown" for "md")
+func normalizeFormat(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "csv":
+		return FormatCSV, nil
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "json":
+		return FormatJSON, nil
+	case "jira-json", "jira":
+		return FormatJiraJSON, nil
+	case "jira-csv":
+		return FormatJiraCSV, nil
+	}
+	return "", fmt.Errorf("unknown format %q (use csv, md, json, jira-json or jira-csv)", format)
+}
+
+// exportFileName builds a file name like "my-project-20250115-093000.csv"
+func exportFileName(board *Board, format string, now time.Time) string {
+	slug := strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(board.Name), "-"), "-")
+	if slug == "" {
+		slug = "board"
+	}
+	return fmt.Sprintf("%s-%s%s", slug, now.Format("20060102-150405"), formatExtensions[format])
+}
+
+// ExportBoard writes the board in the given format
+func ExportBoard(w io.Writer, board *Board, format string) error {
+	switch format {
+	case FormatCSV:
+		return exportCSV(w, board)
+	case FormatMarkdown:
+		return exportMarkdown(w, board)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(BoardToJSON(board, nil, time.Now()))
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// ImportBoard parses a board in the given format
+// Columns are taken from the input where the format has them (Markdown headings,
+// JSON columns), otherwise from the cards' columns in order of appearance
+func ImportBoard(r io.Reader, format string) (*Board, error) {
+	var board *Board
+	var err error
+
+	switch format {
+	case FormatCSV:
+		board, err = importCSV(r)
+	case FormatMarkdown:
+		board, err = importMarkdown(r)
+	case FormatJSON:
+		board, err = importJSON(r)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure every card's column exists on the board
+	for _, card := range board.Cards {
+		if card.Column == "" {
+			continue
+		}
+		if _, ok := board.ResolveColumn(card.Column); !ok {
+			board.Columns = append(board.Columns, Column{Name: card.Column})
+		}
+	}
+	board.PopulateColumnCards()
+	return board, nil
+}
+
+// csvHeaders are the CSV columns: the table view's columns, then the remaining card fields
+var csvHeaders = []string{"Title", "Column", "Assignee", "Due Date", "Created", "Modified", "ID", "Tags", "Description", "URL"}
+
+// exportCSV writes one row per card
+func exportCSV(w io.Writer, board *Board) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeaders); err != nil {
+		return err
+	}
+
+	for _, card := range board.Cards {
+		row := []string{
+			card.Title,
+			card.Column,
+			card.Assignee,
+			card.DueDate,
+			formatCSVTime(card.CreatedAt),
+			formatCSVTime(card.ModifiedAt),
+			card.ID,
+			strings.Join(card.Tags, ","),
+			card.Description,
+			card.URL,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// importCSV reads cards from CSV, matching columns by header name (case-insensitive)
+// Only a Title column is required
+func importCSV(r io.Reader) (*Board, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty CSV")
+	}
+
+	index := map[string]int{}
+	for i, header := range records[0] {
+		index[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+	if _, ok := index["title"]; !ok {
+		return nil, fmt.Errorf("CSV has no Title column")
+	}
+
+	field := func(row []string, name string) string {
+		if i, ok := index[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	board := &Board{}
+	for _, row := range records[1:] {
+		card := &Card{
+			ID:          field(row, "id"),
+			Title:       field(row, "title"),
+			Description: field(row, "description"),
+			Tags:        splitList(field(row, "tags")),
+			Assignee:    field(row, "assignee"),
+			DueDate:     field(row, "due date"),
+			URL:         field(row, "url"),
+			Column:      field(row, "column"),
+			CreatedAt:   parseCSVTime(field(row, "created")),
+			ModifiedAt:  parseCSVTime(field(row, "modified")),
+		}
+		if card.Title == "" {
+			continue
+		}
+		board.Cards = append(board.Cards, card)
+	}
+
+	return board, nil
+}
+
+// formatCSVTime formats a timestamp for CSV (empty for the zero time)
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// parseCSVTime parses an RFC 3339 or YYYY-MM-DD timestamp (zero time if invalid)
+func parseCSVTime(s string) time.Time {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t
+	}
+	if t, err := time.Parse(dueDateLayout, s); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// Markdown checklist format:
+//
+//	# Board Name
+//
+//	## TODO
+//
+//	- [ ] Fix login flow — @alice, due 2025-01-15, #bug #p1 <!-- id:1 -->
+//	  Description lines are indented under the item.
+//	- [x] [Review PR](https://github.com/...) <!-- id:2 -->
+//
+// Cards in DONE and ARCHIVE are checked.
+var (
+	mdItemPattern   = regexp.MustCompile(`^[-*] \[([ xX])\] (.*)$`)
+	mdIDPattern     = regexp.MustCompile(`\s*<!-- id:(\S*) -->\s*$`)
+	mdLinkPattern   = regexp.MustCompile(`^\[(.*)\]\((\S+)\)$`)
+	mdMetaSeparator = " — "
+)
+
+// exportMarkdown writes one heading per column and a checklist item per card
+func exportMarkdown(w io.Writer, board *Board) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# %s\n", board.Name)
+	if board.Description != "" {
+		fmt.Fprintf(bw, "\n%s\n", board.Description)
+	}
+
+	for _, col := range board.Columns {
+		fmt.Fprintf(bw, "\n## %s\n\n", col.Name)
+		for _, card := range col.Cards {
+			fmt.Fprintln(bw, markdownItem(card))
+			if card.Description != "" {
+				for _, line := range strings.Split(card.Description, "\n") {
+					fmt.Fprintf(bw, "  %s\n", line)
+				}
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// markdownItem renders a card as a checklist line
+func markdownItem(card *Card) string {
+	check := " "
+	if card.Column == "DONE" || card.Column == "ARCHIVE" {
+		check = "x"
+	}
+
+	line := fmt.Sprintf("- [%s] %s", check, markdownItemText(card))
+	if card.ID != "" {
+		line += fmt.Sprintf(" <!-- id:%s -->", card.ID)
+	}
+	return line
+}
+
+// markdownItemText renders the text of a checklist item: the title (linked if the
+// card has a URL) followed by the assignees, due date and tags
+// Metadata is written in the form parseMarkdownMeta reads back: each assignee gets
+// an @, due dates are normalized and spaces or commas in names and tags become dashes
+func markdownItemText(card *Card) string {
+	title := card.Title
+	if card.URL != "" {
+		title = fmt.Sprintf("[%s](%s)", card.Title, card.URL)
+	}
+
+	var meta []string
+	for _, name := range strings.Split(card.Assignee, ",") {
+		if name = markdownMetaWord(strings.TrimPrefix(strings.TrimSpace(name), "@")); name != "" {
+			meta = append(meta, "@"+name)
+		}
+	}
+	if due, err := NormalizeDueDate(card.DueDate); err == nil && due != "" {
+		meta = append(meta, "due "+due)
+	} else if err != nil && !strings.Contains(card.DueDate, ",") {
+		meta = append(meta, "due "+strings.TrimSpace(card.DueDate))
+	}
+	var tags []string
+	for _, tag := range card.Tags {
+		if tag = markdownMetaWord(tag); tag != "" {
+			tags = append(tags, "#"+tag)
+		}
+	}
+	if len(tags) > 0 {
+		meta = append(meta, strings.Join(tags, " "))
+	}
+
+	if len(meta) > 0 {
+		return title + mdMetaSeparator + strings.Join(meta, ", ")
+	}
+	return title
+}
+
+// markdownMetaWord joins the words of an assignee or tag with dashes, since item
+// metadata is split on commas and tags on spaces
+func markdownMetaWord(s string) string {
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	}), "-")
+}
+
+// importMarkdown reads a Markdown checklist (see exportMarkdown for the format)
+func importMarkdown(r io.Reader) (*Board, error) {
+	board := &Board{}
+	var column string
+	var current *Card
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "# ") && board.Name == "":
+			board.Name = strings.TrimSpace(line[2:])
+			current = nil
+		case strings.HasPrefix(line, "## "):
+			column = strings.TrimSpace(line[3:])
+			board.Columns = append(board.Columns, Column{Name: column})
+			current = nil
+		case mdItemPattern.MatchString(line):
+			m := mdItemPattern.FindStringSubmatch(line)
+			current = parseMarkdownItem(m[2])
+			current.Column = column
+			board.Cards = append(board.Cards, current)
+		case current != nil && strings.HasPrefix(line, "  "):
+			// Indented lines continue the current card's description
+			if current.Description != "" {
+				current.Description += "\n"
+			}
+			current.Description += strings.TrimPrefix(line, "  ")
+		default:
+			current = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Markdown: %w", err)
+	}
+	return board, nil
+}
+
+// parseMarkdownItem parses the text of a checklist item into a card
+func parseMarkdownItem(text string) *Card {
+	card := &Card{}
+
+	if m := mdIDPattern.FindStringSubmatch(text); m != nil {
+		card.ID = m[1]
+		text = text[:len(text)-len(m[0])]
+	}
+
+	// Metadata follows the last separator, if every part of it parses
+	if i := strings.LastIndex(text, mdMetaSeparator); i >= 0 {
+		if parseMarkdownMeta(text[i+len(mdMetaSeparator):], card) {
+			text = text[:i]
+		}
+	}
+
+	text = strings.TrimSpace(text)
+	if m := mdLinkPattern.FindStringSubmatch(text); m != nil {
+		card.Title = m[1]
+		card.URL = m[2]
+	} else {
+		card.Title = text
+	}
+	return card
+}
+
+// parseMarkdownMeta parses "@alice, @bob, due 2025-01-15, #bug #p1" into card
+// Returns false (leaving card untouched) if any part isn't recognized
+func parseMarkdownMeta(meta string, card *Card) bool {
+	var assignees []string
+	var due string
+	var tags []string
+
+	for _, part := range strings.Split(meta, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case strings.HasPrefix(part, "@") && !strings.Contains(part, " "):
+			assignees = append(assignees, part)
+		case strings.HasPrefix(part, "due "):
+			due = strings.TrimSpace(strings.TrimPrefix(part, "due "))
+		case strings.HasPrefix(part, "#"):
+			for _, tag := range strings.Fields(part) {
+				if !strings.HasPrefix(tag, "#") {
+					return false
+				}
+				tags = append(tags, strings.TrimPrefix(tag, "#"))
+			}
+		default:
+			return false
+		}
+	}
+
+	card.Assignee = strings.Join(assignees, ", ")
+	card.DueDate = due
+	card.Tags = tags
+	return true
+}
+
+// importJSON reads a JSON board document, card document or card array
+func importJSON(r io.Reader) (*Board, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON: %w", err)
+	}
+
+	items, err := DecodeCardsJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	// Board documents also carry the board's name and column order
+	board := &Board{}
+	var doc BoardJSON
+	if json.Unmarshal(data, &doc) == nil {
+		board.Name = doc.Name
+		board.Description = doc.Description
+		board.URL = doc.URL
+		for _, col := range doc.Columns {
+			board.Columns = append(board.Columns, Column{Name: col.Name})
+		}
+	}
+
+	for _, item := range items {
+		board.Cards = append(board.Cards, item.ToCard())
+	}
+	return board, nil
+}
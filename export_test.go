@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	original := &Board{
+		Name:    "Release",
+		Columns: []Column{{Name: "TODO"}, {Name: "PROGRESS"}, {Name: "DONE"}},
+		Cards: []*Card{
+			{ID: "1", Title: "Fix login, again", Description: "Steps:\n\n1. Log in\n2. \"Crash\"", Tags: []string{"bug", "p1"},
+				Assignee: "@alice", DueDate: "2026-02-01", Column: "TODO", CreatedAt: created, ModifiedAt: created},
+			{ID: "2", Title: "Add OAuth", URL: "https://example.com/issues/2", Column: "PROGRESS", CreatedAt: created, ModifiedAt: created},
+			{ID: "7", Title: "Ship — finally", Tags: []string{"release"}, Column: "DONE", CreatedAt: created, ModifiedAt: created},
+		},
+	}
+	original.PopulateColumnCards()
+
+	for _, format := range exportFormats {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := ExportBoard(&buf, original, format); err != nil {
+				t.Fatal(err)
+			}
+			imported, err := ImportBoard(&buf, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join(t.TempDir(), ".tkan.yaml")
+			if err := SaveBoard(path, imported); err != nil {
+				t.Fatal(err)
+			}
+			board, err := LoadBoard(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(board.Columns) != len(original.Columns) {
+				t.Errorf("columns = %+v", board.Columns)
+			}
+			if len(board.Cards) != len(original.Cards) {
+				t.Fatalf("got %d cards, want %d", len(board.Cards), len(original.Cards))
+			}
+			for i, want := range original.Cards {
+				got := board.Cards[i]
+				if got.ID != want.ID || !cardsEqual(got, want) {
+					t.Errorf("card %d = %+v, want %+v", i, got, want)
+				}
+				// Markdown has nowhere to keep timestamps
+				if format != FormatMarkdown && !got.CreatedAt.Equal(want.CreatedAt) {
+					t.Errorf("card %d created %s, want %s", i, got.CreatedAt, want.CreatedAt)
+				}
+			}
+			if format != FormatCSV && board.Name != original.Name {
+				t.Errorf("name = %q", board.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitHubIssue is a repository issue fetched for `tkan import github-issues`
+type GitHubIssue struct {
+	Number    int
+	Title     string
+	Body      string
+	URL       string
+	State     string // OPEN or CLOSED
+	Milestone string // Milestone title (empty if none)
+	Labels    []string
+	Assignees []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// GitHubIssueFilter selects which repository issues are imported
+type GitHubIssueFilter struct {
+	State     string   // open, closed or all (default open)
+	Labels    []string // Issues must have every label
+	Milestone string   // Milestone title or number (empty = any)
+}
+
+// githubIssueFields are the fields of an issue the import reads (see githubIssueNode)
+const githubIssueFields = `id number title body url state createdAt updatedAt
+				milestone { title number }
+				assignees(first: 10) { nodes { login } }
+				labels(first: 100) { pageInfo { hasNextPage endCursor } nodes { id name } }`
+
+// githubRepoIssuesQuery fetches one page of a repository's issues
+// The labels argument matches any of the labels; requiring all of them is done client side
+const githubRepoIssuesQuery = `
+query($owner: String!, $name: String!, $first: Int!, $cursor: String, $states: [IssueState!], $labels: [String!]) {
+	repository(owner: $owner, name: $name) {
+		issues(first: $first, after: $cursor, states: $states, labels: $labels, orderBy: {field: CREATED_AT, direction: ASC}) {
+			pageInfo { hasNextPage endCursor }
+			nodes {
+				` + githubIssueFields + `
+			}
+		}
+	}
+}`
+
+// githubIssueNode is an issue as returned by githubRepoIssuesQuery
+type githubIssueNode struct {
+	ID        string    `json:"id"`
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	URL       string    `json:"url"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Milestone *struct {
+		Title  string `json:"title"`
+		Number int    `json:"number"`
+	} `json:"milestone"`
+	Assignees githubLogins          `json:"assignees"`
+	Labels    githubLabelConnection `json:"labels"`
+}
+
+// fetchAllLabels replaces the first page of labels with all of them when an issue
+// has more than fit in the page, so updates don't drop the rest from the card's tags
+func (n *githubIssueNode) fetchAllLabels(gql GraphQLClient) error {
+	if !n.Labels.PageInfo.HasNextPage {
+		return nil
+	}
+	labels, err := contentLabels(gql, githubContentLabelsQuery, n.ID)
+	if err != nil {
+		return err
+	}
+	n.Labels.Nodes = labels
+	n.Labels.PageInfo.HasNextPage = false
+	return nil
+}
+
+// ListGitHubIssues fetches the issues of owner/repo that match the filter
+func ListGitHubIssues(gql GraphQLClient, owner, repo string, filter GitHubIssueFilter) ([]GitHubIssue, error) {
+	var states []string
+	switch strings.ToLower(filter.State) {
+	case "", "open":
+		states = []string{"OPEN"}
+	case "closed":
+		states = []string{"CLOSED"}
+	case "all":
+	default:
+		return nil, fmt.Errorf("state must be open, closed or all, not %q", filter.State)
+	}
+
+	var issues []GitHubIssue
+	cursor := ""
+	for {
+		variables := map[string]interface{}{
+			"owner": owner,
+			"name":  repo,
+			"first": githubItemsPageSize,
+		}
+		if cursor != "" {
+			variables["cursor"] = cursor
+		}
+		if states != nil {
+			variables["states"] = states
+		}
+		if len(filter.Labels) > 0 {
+			variables["labels"] = filter.Labels
+		}
+
+		var result struct {
+			Repository *struct {
+				Issues struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []githubIssueNode `json:"nodes"`
+				} `json:"issues"`
+			} `json:"repository"`
+		}
+		if err := gql.Do(githubRepoIssuesQuery, variables, &result); err != nil {
+			return nil, err
+		}
+		if result.Repository == nil {
+			return nil, fmt.Errorf("repository not found: %s/%s", owner, repo)
+		}
+
+		page := result.Repository.Issues
+		for _, node := range page.Nodes {
+			if err := node.fetchAllLabels(gql); err != nil {
+				return nil, err
+			}
+			if node.matches(filter) {
+				issues = append(issues, node.toIssue())
+			}
+		}
+
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			break
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+
+	return issues, nil
+}
+
+// ListClosedGitHubIssues fetches issues of owner/repo by number and returns the
+// closed ones; issues that were deleted or transferred are skipped
+// Importing open issues uses it to move the cards of issues closed since the last run
+func ListClosedGitHubIssues(gql GraphQLClient, owner, repo string, numbers []int) ([]GitHubIssue, error) {
+	var issues []GitHubIssue
+	for start := 0; start < len(numbers); start += githubItemsPageSize {
+		batch := numbers[start:min(start+githubItemsPageSize, len(numbers))]
+		variables := map[string]interface{}{"owner": owner, "name": repo}
+		var params, fields []string
+		for i, number := range batch {
+			params = append(params, fmt.Sprintf("$n%d: Int!", i))
+			fields = append(fields, fmt.Sprintf("i%d: issue(number: $n%d) { %s }", i, i, githubIssueFields))
+			variables[fmt.Sprintf("n%d", i)] = number
+		}
+		query := fmt.Sprintf("query($owner: String!, $name: String!, %s) {\n\trepository(owner: $owner, name: $name) {\n\t\t%s\n\t}\n}",
+			strings.Join(params, ", "), strings.Join(fields, "\n\t\t"))
+
+		var result struct {
+			Repository map[string]*githubIssueNode `json:"repository"`
+		}
+		if err := gql.Do(query, variables, &result); err != nil {
+			var gqlErr GraphQLError
+			if !errors.As(err, &gqlErr) || gqlErr.Type != "NOT_FOUND" {
+				return nil, err
+			}
+			if len(batch) == 1 {
+				continue
+			}
+			// A missing issue fails the whole batch, so look its issues up one by one
+			for _, number := range batch {
+				found, err := ListClosedGitHubIssues(gql, owner, repo, []int{number})
+				if err != nil {
+					return nil, err
+				}
+				issues = append(issues, found...)
+			}
+			continue
+		}
+		if result.Repository == nil {
+			return nil, fmt.Errorf("repository not found: %s/%s", owner, repo)
+		}
+
+		for i := range batch {
+			if node := result.Repository[fmt.Sprintf("i%d", i)]; node != nil && node.State == "CLOSED" {
+				if err := node.fetchAllLabels(gql); err != nil {
+					return nil, err
+				}
+				issues = append(issues, node.toIssue())
+			}
+		}
+	}
+	return issues, nil
+}
+
+// matches applies the filters the API can't: all labels and the milestone
+func (n githubIssueNode) matches(filter GitHubIssueFilter) bool {
+	for _, want := range filter.Labels {
+		found := false
+		for _, l := range n.Labels.Nodes {
+			if strings.EqualFold(l.Name, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if filter.Milestone == "" {
+		return true
+	}
+	if n.Milestone == nil {
+		return false
+	}
+	if number, err := strconv.Atoi(filter.Milestone); err == nil && number == n.Milestone.Number {
+		return true
+	}
+	return strings.EqualFold(n.Milestone.Title, filter.Milestone)
+}
+
+// toIssue converts an API issue node
+func (n githubIssueNode) toIssue() GitHubIssue {
+	issue := GitHubIssue{
+		Number:    n.Number,
+		Title:     n.Title,
+		Body:      n.Body,
+		URL:       n.URL,
+		State:     n.State,
+		Assignees: n.Assignees.logins(),
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+	}
+	if n.Milestone != nil {
+		issue.Milestone = n.Milestone.Title
+	}
+	for _, l := range n.Labels.Nodes {
+		issue.Labels = append(issue.Labels, l.Name)
+	}
+	return issue
+}
+
+// IssueColumnRules place imported issues in board columns
+// Closed issues go to Closed; open issues go to the column of their first label
+// with a rule, else to Open
+type IssueColumnRules struct {
+	Labels []IssueLabelRule
+	Open   string // Default column for open issues (default: the board's first column)
+	Closed string // Column for closed issues (default: DONE, else the board's last column)
+}
+
+// IssueLabelRule maps an issue label to a column
+type IssueLabelRule struct {
+	Label  string
+	Column string
+}
+
+// ParseIssueLabelRules parses "label=COLUMN,label=COLUMN"
+func ParseIssueLabelRules(spec string) ([]IssueLabelRule, error) {
+	var rules []IssueLabelRule
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		label, column, ok := strings.Cut(part, "=")
+		label, column = strings.TrimSpace(label), strings.TrimSpace(column)
+		if !ok || label == "" || column == "" {
+			return nil, fmt.Errorf("invalid column rule %q (want label=COLUMN)", part)
+		}
+		rules = append(rules, IssueLabelRule{Label: label, Column: column})
+	}
+	return rules, nil
+}
+
+// closedColumn returns the column for closed issues
+func (r IssueColumnRules) closedColumn(board *Board) string {
+	if r.Closed != "" {
+		return r.Closed
+	}
+	if name, ok := board.ResolveColumn("DONE"); ok {
+		return name
+	}
+	if len(board.Columns) > 0 {
+		return board.Columns[len(board.Columns)-1].Name
+	}
+	return ""
+}
+
+// column returns the column for an issue and whether a rule (rather than the
+// open default) chose it
+func (r IssueColumnRules) column(board *Board, issue GitHubIssue) (string, bool) {
+	if issue.State == "CLOSED" {
+		return r.closedColumn(board), true
+	}
+
+	for _, rule := range r.Labels {
+		for _, label := range issue.Labels {
+			if strings.EqualFold(label, rule.Label) {
+				return rule.Column, true
+			}
+		}
+	}
+	return r.Open, false
+}
+
+// issueToCard converts an issue to a card with the fields the import sets
+func issueToCard(issue GitHubIssue, column string) *Card {
+	card := &Card{
+		Title:       issue.Title,
+		Description: issue.Body,
+		Tags:        issue.Labels,
+		URL:         issue.URL,
+		Column:      column,
+		CreatedAt:   issue.CreatedAt,
+	}
+	if len(issue.Assignees) > 0 {
+		card.Assignee = "@" + strings.Join(issue.Assignees, ", @")
+	}
+	return card
+}
+
+// OpenImportedIssues returns the numbers of owner/repo issues that have a card on
+// the board outside the closed and ARCHIVE columns but aren't among fetched; they
+// may have been closed since they were imported (see ListClosedGitHubIssues)
+func OpenImportedIssues(board *Board, rules IssueColumnRules, owner, repo string, fetched []GitHubIssue) []int {
+	seen := map[string]bool{}
+	for _, issue := range fetched {
+		seen[issue.URL] = true
+	}
+	closed := rules.closedColumn(board)
+
+	var numbers []int
+	for _, card := range board.Cards {
+		if card.URL == "" || seen[card.URL] || strings.EqualFold(card.Column, closed) || strings.EqualFold(card.Column, "ARCHIVE") {
+			continue
+		}
+		// https://<host>/<owner>/<repo>/issues/<number>
+		prefix, number, ok := strings.Cut(card.URL, "/issues/")
+		n, err := strconv.Atoi(number)
+		if ok && err == nil && strings.HasSuffix(strings.ToLower(prefix), strings.ToLower("/"+owner+"/"+repo)) {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
+// ImportGitHubIssues creates a card for each new issue and updates the cards
+// of issues imported before (matched by URL)
+// Existing cards keep their column unless the issue was closed or a label rule applies,
+// so cards moved on the board aren't moved back; archived cards of closed issues
+// stay archived
+func ImportGitHubIssues(backend Backend, issues []GitHubIssue, rules IssueColumnRules, dryRun bool) (importResult, error) {
+	var result importResult
+	var errs []error
+
+	board, err := backend.LoadBoard()
+	if err != nil {
+		return result, err
+	}
+	byURL := map[string]*Card{}
+	for _, card := range board.Cards {
+		if card.URL != "" {
+			byURL[card.URL] = card
+		}
+	}
+
+	for _, issue := range issues {
+		column, ruled := rules.column(board, issue)
+		existing := byURL[issue.URL]
+		if existing != nil && (!ruled || (issue.State == "CLOSED" && strings.EqualFold(existing.Column, "ARCHIVE"))) {
+			column = existing.Column
+		}
+
+		card := issueToCard(issue, column)
+		if err := normalizeImportedCard(board, card); err != nil {
+			errs = append(errs, fmt.Errorf("issue #%d (%q): %w", issue.Number, issue.Title, err))
+			continue
+		}
+
+		if existing != nil {
+			if githubIssueCardEqual(existing, card) {
+				continue
+			}
+			if !dryRun {
+				card.ID = existing.ID
+				card.DueDate = existing.DueDate
+				card.CreatedAt = existing.CreatedAt
+				if err := updateImportedCard(backend, existing, card); err != nil {
+					errs = append(errs, fmt.Errorf("issue #%d: %w", issue.Number, err))
+					continue
+				}
+			}
+			result.Updated++
+			continue
+		}
+
+		if !dryRun {
+			if err := createImportedCard(backend, card); err != nil {
+				errs = append(errs, fmt.Errorf("issue #%d (%q): %w", issue.Number, issue.Title, err))
+				continue
+			}
+		}
+		result.Created++
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// githubIssueCardEqual reports whether re-importing an issue would leave its card unchanged
+func githubIssueCardEqual(existing, card *Card) bool {
+	return existing.Title == card.Title &&
+		existing.Description == card.Description &&
+		existing.Column == card.Column &&
+		existing.Assignee == card.Assignee &&
+		slices.Equal(existing.Tags, card.Tags)
+}
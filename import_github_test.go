@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// issueNode builds an issue as returned by githubRepoIssuesQuery
+func issueNode(number int, title, state string, labels ...string) map[string]interface{} {
+	var labelNodes []map[string]interface{}
+	for _, l := range labels {
+		labelNodes = append(labelNodes, map[string]interface{}{"name": l})
+	}
+	return map[string]interface{}{
+		"number": number, "title": title, "body": "Body of " + title, "state": state,
+		"url":       "https://github.com/acme/app/issues/" + title,
+		"createdAt": "2026-01-01T00:00:00Z", "updatedAt": "2026-01-02T00:00:00Z",
+		"milestone": map[string]interface{}{"title": "v1", "number": 1},
+		"assignees": map[string]interface{}{"nodes": []map[string]interface{}{{"login": "alice"}}},
+		"labels":    map[string]interface{}{"nodes": labelNodes},
+	}
+}
+
+func TestListGitHubIssuesFilters(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("issues(", func(vars map[string]interface{}) interface{} {
+		nodes := []interface{}{
+			issueNode(1, "both", "OPEN", "bug", "ui"),
+			issueNode(2, "bug-only", "OPEN", "bug"),
+		}
+		if vars["cursor"] == nil {
+			return map[string]interface{}{"repository": map[string]interface{}{"issues": map[string]interface{}{
+				"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "c1"},
+				"nodes":    nodes[:1],
+			}}}
+		}
+		return map[string]interface{}{"repository": map[string]interface{}{"issues": map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": false},
+			"nodes":    nodes[1:],
+		}}}
+	})
+	gql := NewHTTPGraphQLClient(f.server.URL, "test-token")
+
+	issues, err := ListGitHubIssues(gql, "acme", "app", GitHubIssueFilter{Labels: []string{"bug", "UI"}, Milestone: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Number != 1 || issues[0].Milestone != "v1" {
+		t.Errorf("issues = %+v, want only #1", issues)
+	}
+	if states := f.last("issues(").Variables["states"]; states == nil {
+		t.Error("open issues should be requested by default")
+	}
+
+	if issues, _ := ListGitHubIssues(gql, "acme", "app", GitHubIssueFilter{State: "all", Milestone: "v2"}); len(issues) != 0 {
+		t.Errorf("milestone v2 matched %+v", issues)
+	}
+	if _, err := ListGitHubIssues(gql, "acme", "app", GitHubIssueFilter{State: "merged"}); err == nil {
+		t.Error("invalid state should fail")
+	}
+}
+
+func TestListGitHubIssuesFetchesAllLabels(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.on("node(id: $id)", func(vars map[string]interface{}) interface{} {
+		if vars["id"] != "I_1" {
+			t.Errorf("labels fetched for %v", vars["id"])
+		}
+		page := map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "l1"},
+			"nodes":    []map[string]string{{"id": "L_1", "name": "bug"}},
+		}
+		if vars["cursor"] != nil {
+			page = map[string]interface{}{
+				"pageInfo": map[string]interface{}{"hasNextPage": false},
+				"nodes":    []map[string]string{{"id": "L_2", "name": "wontfix"}},
+			}
+		}
+		return map[string]interface{}{"node": map[string]interface{}{"labels": page}}
+	})
+	f.on("issues(", func(map[string]interface{}) interface{} {
+		node := issueNode(1, "many-labels", "OPEN", "bug")
+		node["id"] = "I_1"
+		node["labels"] = map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": true, "endCursor": "l1"},
+			"nodes":    []map[string]string{{"id": "L_1", "name": "bug"}},
+		}
+		return map[string]interface{}{"repository": map[string]interface{}{"issues": map[string]interface{}{
+			"pageInfo": map[string]interface{}{"hasNextPage": false},
+			"nodes":    []interface{}{node},
+		}}}
+	})
+	gql := NewHTTPGraphQLClient(f.server.URL, "test-token")
+
+	// The label filter sees labels beyond the first page too
+	issues, err := ListGitHubIssues(gql, "acme", "app", GitHubIssueFilter{Labels: []string{"wontfix"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || !slices.Equal(issues[0].Labels, []string{"bug", "wontfix"}) {
+		t.Errorf("issues = %+v, want #1 with both labels", issues)
+	}
+}
+
+func TestImportGitHubIssuesIsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tkan.yaml")
+	board := &Board{Name: "Issues", Columns: []Column{{Name: "TODO"}, {Name: "PROGRESS"}, {Name: "DONE"}}}
+	if err := SaveBoard(path, board); err != nil {
+		t.Fatal(err)
+	}
+	backend := NewLocalBackend(path)
+
+	rules := IssueColumnRules{Labels: []IssueLabelRule{{Label: "wip", Column: "progress"}}}
+	issues := []GitHubIssue{
+		{Number: 1, Title: "Plain", State: "OPEN", URL: "https://github.com/acme/app/issues/1", Assignees: []string{"alice", "bob"}},
+		{Number: 2, Title: "Started", State: "OPEN", URL: "https://github.com/acme/app/issues/2", Labels: []string{"wip"}},
+		{Number: 3, Title: "Finished", State: "CLOSED", URL: "https://github.com/acme/app/issues/3"},
+	}
+
+	result, err := ImportGitHubIssues(backend, issues, rules, false)
+	if err != nil || result.Created != 3 {
+		t.Fatalf("first import = %+v, %v", result, err)
+	}
+	board, _ = backend.LoadBoard()
+	columns := map[string]string{}
+	for _, card := range board.Cards {
+		columns[card.Title] = card.Column
+	}
+	if columns["Plain"] != "TODO" || columns["Started"] != "PROGRESS" || columns["Finished"] != "DONE" {
+		t.Errorf("columns = %v", columns)
+	}
+	if card := board.Cards[0]; card.Assignee != "@alice, @bob" || card.URL != issues[0].URL {
+		t.Errorf("card = %+v", card)
+	}
+
+	// The user moves a card; a re-run leaves it there but picks up the closed issue
+	plain := board.Cards[0]
+	if err := backend.MoveCard(plain.ID, "PROGRESS"); err != nil {
+		t.Fatal(err)
+	}
+	issues[0].Title = "Plain, renamed"
+	issues[1].State = "CLOSED"
+	result, err = ImportGitHubIssues(backend, issues, rules, false)
+	if err != nil || result.Created != 0 || result.Updated != 2 {
+		t.Fatalf("second import = %+v, %v", result, err)
+	}
+	board, _ = backend.LoadBoard()
+	if len(board.Cards) != 3 {
+		t.Fatalf("re-import duplicated cards: %d", len(board.Cards))
+	}
+	if card := board.FindCard(plain.ID); card.Title != "Plain, renamed" || card.Column != "PROGRESS" {
+		t.Errorf("moved card = %+v", card)
+	}
+	if card := board.Cards[1]; card.Column != "DONE" {
+		t.Errorf("closed issue's card is in %s", card.Column)
+	}
+}
+
+func TestImportGitHubIssuesKeepsArchivedCards(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tkan.yaml")
+	board := &Board{Name: "Issues", Columns: []Column{{Name: "TODO"}, {Name: "DONE"}, {Name: "ARCHIVE"}}}
+	if err := SaveBoard(path, board); err != nil {
+		t.Fatal(err)
+	}
+	backend := NewLocalBackend(path)
+	issues := []GitHubIssue{{Number: 1, Title: "Old", State: "CLOSED", URL: "https://github.com/acme/app/issues/1"}}
+	if _, err := ImportGitHubIssues(backend, issues, IssueColumnRules{}, false); err != nil {
+		t.Fatal(err)
+	}
+	board, _ = backend.LoadBoard()
+	if err := backend.MoveCard(board.Cards[0].ID, "ARCHIVE"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-importing closed issues doesn't bring archived cards back
+	if _, err := ImportGitHubIssues(backend, issues, IssueColumnRules{}, false); err != nil {
+		t.Fatal(err)
+	}
+	board, _ = backend.LoadBoard()
+	if card := board.Cards[0]; card.Column != "ARCHIVE" {
+		t.Errorf("archived card moved to %s", card.Column)
+	}
+}
+
+func TestImportGitHubIssuesKeepsSyncPairing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tkan.yaml")
+	board := &Board{Name: "Issues", Columns: []Column{{Name: "TODO"}, {Name: "DONE"}}}
+	if err := SaveBoard(path, board); err != nil {
+		t.Fatal(err)
+	}
+	backend := NewLocalBackend(path)
+	issues := []GitHubIssue{{Number: 1, Title: "Synced", State: "OPEN", URL: "https://github.com/acme/app/issues/1"}}
+	if _, err := ImportGitHubIssues(backend, issues, IssueColumnRules{}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// tkan sync pairs the card with a project item
+	board, _ = backend.LoadBoard()
+	synced := board.Cards[0]
+	synced.RemoteID, synced.ContentType, synced.ContentID = "PVTI_1", "Issue", "I_1"
+	if err := backend.UpdateCard(synced); err != nil {
+		t.Fatal(err)
+	}
+
+	issues[0].Title = "Synced, renamed"
+	if result, err := ImportGitHubIssues(backend, issues, IssueColumnRules{}, false); err != nil || result.Updated != 1 {
+		t.Fatalf("re-import = %+v, %v", result, err)
+	}
+	board, _ = backend.LoadBoard()
+	card := board.Cards[0]
+	if card.Title != "Synced, renamed" || card.RemoteID != "PVTI_1" || card.ContentType != "Issue" || card.ContentID != "I_1" {
+		t.Errorf("re-imported card = %+v, want its sync pairing kept", card)
+	}
+}
+
+func TestListClosedGitHubIssues(t *testing.T) {
+	board := &Board{Columns: []Column{{Name: "TODO"}, {Name: "DONE"}, {Name: "ARCHIVE"}}, Cards: []*Card{
+		{ID: "1", Column: "TODO", URL: "https://github.com/acme/app/issues/1"},
+		{ID: "2", Column: "TODO", URL: "https://github.com/acme/app/issues/2"},
+		{ID: "3", Column: "TODO", URL: "https://github.com/acme/app/issues/3"},
+		{ID: "4", Column: "DONE", URL: "https://github.com/acme/app/issues/4"},
+		{ID: "5", Column: "ARCHIVE", URL: "https://github.com/acme/app/issues/5"},
+		{ID: "6", Column: "TODO", URL: "https://github.com/acme/other/issues/6"},
+	}}
+	fetched := []GitHubIssue{{Number: 1, URL: "https://github.com/acme/app/issues/1"}}
+	numbers := OpenImportedIssues(board, IssueColumnRules{}, "acme", "app", fetched)
+	if !slices.Equal(numbers, []int{2, 3}) {
+		t.Fatalf("numbers = %v, want [2 3]", numbers)
+	}
+
+	// Issue 2 was closed and issue 3 deleted
+	f := newFakeGitHub(t)
+	f.on("issue(number:", func(vars map[string]interface{}) interface{} {
+		issues := map[string]interface{}{}
+		for i := 0; vars[fmt.Sprintf("n%d", i)] != nil; i++ {
+			switch vars[fmt.Sprintf("n%d", i)] {
+			case 2.0:
+				issues[fmt.Sprintf("i%d", i)] = issueNode(2, "2", "CLOSED")
+			case 3.0:
+				return GraphQLError{Type: "NOT_FOUND", Message: "Could not resolve to an Issue with the number of 3."}
+			}
+		}
+		return map[string]interface{}{"repository": issues}
+	})
+	issues, err := ListClosedGitHubIssues(NewHTTPGraphQLClient(f.server.URL, "test-token"), "acme", "app", numbers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Number != 2 || issues[0].State != "CLOSED" {
+		t.Errorf("issues = %+v, want only #2", issues)
+	}
+}
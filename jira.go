@@ -0,0 +1,529 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Jira export formats (import/export only; not offered in the TUI export picker)
+const (
+	FormatJiraJSON = "jira-json" // Jira REST search results ({"issues": [...]})
+	FormatJiraCSV  = "jira-csv"  // Jira's "Export issues > CSV"
+)
+
+// isJiraFormat reports whether format is one of the Jira formats
+func isJiraFormat(format string) bool {
+	return format == FormatJiraJSON || format == FormatJiraCSV
+}
+
+// jiraKeyPattern matches Jira issue keys like "PROJ-123"
+var jiraKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+
+// JiraMapping maps Jira statuses to board columns
+// Loaded from a YAML file (--jira-mapping, or ~/.config/tkan/jira.yaml):
+//
+//	base_url: https://acme.atlassian.net   # Links cards to /browse/<key>
+//	statuses:
+//	  Selected for Development: TODO
+//	  Code Review: REVIEW
+//	columns:                               # Status written for a column on export
+//	  REVIEW: Code Review
+//
+// Entries are added to the defaults in defaultJiraStatuses
+type JiraMapping struct {
+	BaseURL  string            `yaml:"base_url,omitempty"`
+	Statuses map[string]string `yaml:"statuses,omitempty"` // Jira status -> column
+	Columns  map[string]string `yaml:"columns,omitempty"`  // Column -> Jira status (export)
+}
+
+// defaultJiraStatuses maps the statuses of Jira's default workflows to the default columns
+var defaultJiraStatuses = map[string]string{
+	"Backlog":     "BACKLOG",
+	"Open":        "TODO",
+	"To Do":       "TODO",
+	"Reopened":    "TODO",
+	"In Progress": "PROGRESS",
+	"In Review":   "REVIEW",
+	"Review":      "REVIEW",
+	"Done":        "DONE",
+	"Closed":      "DONE",
+	"Resolved":    "DONE",
+}
+
+// defaultJiraColumns is the status written for each default column on export
+var defaultJiraColumns = map[string]string{
+	"BACKLOG":  "Backlog",
+	"TODO":     "To Do",
+	"PROGRESS": "In Progress",
+	"REVIEW":   "In Review",
+	"DONE":     "Done",
+	"ARCHIVE":  "Done",
+}
+
+// jiraMappingPath returns the default mapping file (~/.config/tkan/jira.yaml)
+func jiraMappingPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jira.yaml"), nil
+}
+
+// LoadJiraMapping reads a status mapping file on top of the defaults
+// An empty path reads ~/.config/tkan/jira.yaml if it exists
+func LoadJiraMapping(path string) (*JiraMapping, error) {
+	mapping := &JiraMapping{Statuses: map[string]string{}, Columns: map[string]string{}}
+	for status, column := range defaultJiraStatuses {
+		mapping.Statuses[status] = column
+	}
+	for column, status := range defaultJiraColumns {
+		mapping.Columns[column] = status
+	}
+
+	explicit := path != ""
+	if !explicit {
+		var err error
+		if path, err = jiraMappingPath(); err != nil {
+			return mapping, nil
+		}
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !explicit {
+		return mapping, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file JiraMapping
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	mapping.BaseURL = strings.TrimSuffix(file.BaseURL, "/")
+	for status, column := range file.Statuses {
+		mapping.Statuses[status] = column
+	}
+	for column, status := range file.Columns {
+		mapping.Columns[column] = status
+	}
+	return mapping, nil
+}
+
+// column returns the board column for a Jira status (matched case-insensitively)
+// Unmapped statuses become columns of their own, named after the status
+func (m *JiraMapping) column(status string) string {
+	for name, column := range m.Statuses {
+		if strings.EqualFold(name, status) {
+			return column
+		}
+	}
+	return strings.ToUpper(status)
+}
+
+// status returns the Jira status for a board column
+func (m *JiraMapping) status(column string) string {
+	for name, status := range m.Columns {
+		if strings.EqualFold(name, column) {
+			return status
+		}
+	}
+	// Fall back to any status mapped to the column (alphabetically first, so exports are stable)
+	var statuses []string
+	for status, col := range m.Statuses {
+		if strings.EqualFold(col, column) {
+			statuses = append(statuses, status)
+		}
+	}
+	if len(statuses) > 0 {
+		slices.Sort(statuses)
+		return statuses[0]
+	}
+	return column
+}
+
+// browseURL links a Jira issue key (empty if the base URL is unknown)
+func (m *JiraMapping) browseURL(key string) string {
+	if m.BaseURL == "" || key == "" {
+		return ""
+	}
+	return m.BaseURL + "/browse/" + key
+}
+
+// jiraCardKey returns the Jira key of a card, from its ID or its browse URL
+func jiraCardKey(card *Card) string {
+	if jiraKeyPattern.MatchString(card.ID) {
+		return card.ID
+	}
+	if i := strings.LastIndex(card.URL, "/browse/"); i >= 0 {
+		if key := card.URL[i+len("/browse/"):]; jiraKeyPattern.MatchString(key) {
+			return key
+		}
+	}
+	return ""
+}
+
+// ImportJira parses a Jira JSON or CSV export into a board
+// Cards take the issue key as their ID and link to the issue when the base URL is known
+func ImportJira(r io.Reader, format string, mapping *JiraMapping) (*Board, error) {
+	var board *Board
+	var err error
+	switch format {
+	case FormatJiraJSON:
+		board, err = importJiraJSON(r, mapping)
+	case FormatJiraCSV:
+		board, err = importJiraCSV(r, mapping)
+	default:
+		return nil, fmt.Errorf("unknown Jira format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, card := range board.Cards {
+		if _, ok := board.ResolveColumn(card.Column); !ok {
+			board.Columns = append(board.Columns, Column{Name: card.Column})
+		}
+	}
+	board.PopulateColumnCards()
+	return board, nil
+}
+
+// ExportJira writes a board as a Jira JSON or CSV export
+func ExportJira(w io.Writer, board *Board, format string, mapping *JiraMapping) error {
+	switch format {
+	case FormatJiraJSON:
+		return exportJiraJSON(w, board, mapping)
+	case FormatJiraCSV:
+		return exportJiraCSV(w, board, mapping)
+	}
+	return fmt.Errorf("unknown Jira format %q", format)
+}
+
+// jiraTimeLayout is the timestamp format of the Jira REST API
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// jiraCSVTimeLayout is the default date format of Jira CSV exports
+const jiraCSVTimeLayout = "02/Jan/06 3:04 PM"
+
+// parseJiraTime parses the timestamp and date formats found in Jira exports (zero time if invalid)
+func parseJiraTime(s string) time.Time {
+	for _, layout := range []string{jiraTimeLayout, time.RFC3339, jiraCSVTimeLayout, "02/Jan/06", dueDateLayout} {
+		if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseJiraDueDate converts a Jira due date to YYYY-MM-DD (empty if missing or invalid)
+func parseJiraDueDate(s string) string {
+	if t := parseJiraTime(s); !t.IsZero() {
+		return t.Format(dueDateLayout)
+	}
+	return ""
+}
+
+// jiraIssue is an issue in Jira's REST format
+type jiraIssue struct {
+	Key    string     `json:"key"`
+	Self   string     `json:"self,omitempty"` // REST URL, e.g. https://acme.atlassian.net/rest/api/2/issue/10001
+	Fields jiraFields `json:"fields"`
+}
+
+// jiraFields are the issue fields tkan maps onto cards
+type jiraFields struct {
+	Summary     string          `json:"summary"`
+	Description json.RawMessage `json:"description,omitempty"` // Plain text (API v2) or an Atlassian document (v3)
+	Status      *jiraNamed      `json:"status,omitempty"`
+	Labels      []string        `json:"labels"`
+	Assignee    *jiraUser       `json:"assignee"`
+	DueDate     string          `json:"duedate,omitempty"`
+	Created     string          `json:"created,omitempty"`
+	Updated     string          `json:"updated,omitempty"`
+}
+
+// jiraNamed is a Jira object identified by name (status, priority, ...)
+type jiraNamed struct {
+	Name string `json:"name"`
+}
+
+// jiraUser is a Jira user reference
+type jiraUser struct {
+	DisplayName  string `json:"displayName,omitempty"`
+	Name         string `json:"name,omitempty"` // Jira Server/Data Center username
+	EmailAddress string `json:"emailAddress,omitempty"`
+}
+
+// String returns the user's display name, falling back to the username or email
+func (u *jiraUser) String() string {
+	switch {
+	case u == nil:
+		return ""
+	case u.DisplayName != "":
+		return u.DisplayName
+	case u.Name != "":
+		return u.Name
+	}
+	return u.EmailAddress
+}
+
+// importJiraJSON reads {"issues": [...]} search results or a bare array of issues
+func importJiraJSON(r io.Reader, mapping *JiraMapping) (*Board, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON: %w", err)
+	}
+
+	var issues []jiraIssue
+	var doc struct {
+		Issues []jiraIssue `json:"issues"`
+	}
+	if err := json.Unmarshal(data, &doc); err == nil && doc.Issues != nil {
+		issues = doc.Issues
+	} else if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, fmt.Errorf("failed to parse Jira JSON: %w", err)
+	}
+
+	board := &Board{}
+	for _, issue := range issues {
+		if issue.Fields.Summary == "" {
+			continue
+		}
+		status := ""
+		if issue.Fields.Status != nil {
+			status = issue.Fields.Status.Name
+		}
+
+		card := &Card{
+			ID:          issue.Key,
+			Title:       issue.Fields.Summary,
+			Description: jiraDescription(issue.Fields.Description),
+			Tags:        issue.Fields.Labels,
+			Assignee:    issue.Fields.Assignee.String(),
+			DueDate:     parseJiraDueDate(issue.Fields.DueDate),
+			Column:      mapping.column(status),
+			CreatedAt:   parseJiraTime(issue.Fields.Created),
+			ModifiedAt:  parseJiraTime(issue.Fields.Updated),
+			URL:         mapping.browseURL(issue.Key),
+		}
+		// Issues link to their own site when the mapping doesn't name one
+		if card.URL == "" && issue.Key != "" {
+			if i := strings.Index(issue.Self, "/rest/api/"); i > 0 {
+				card.URL = issue.Self[:i] + "/browse/" + issue.Key
+			}
+		}
+		board.Cards = append(board.Cards, card)
+	}
+	return board, nil
+}
+
+// jiraDescription returns the text of a description in either API format
+func jiraDescription(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return text
+	}
+
+	var doc adfNode
+	if json.Unmarshal(raw, &doc) != nil {
+		return ""
+	}
+	var b strings.Builder
+	doc.writeText(&b)
+	return strings.TrimSpace(b.String())
+}
+
+// adfNode is a node of an Atlassian Document Format description
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text,omitempty"`
+	Content []adfNode `json:"content,omitempty"`
+}
+
+// writeText writes the plain text of a node, one line per paragraph
+func (n adfNode) writeText(b *strings.Builder) {
+	switch n.Type {
+	case "text":
+		b.WriteString(n.Text)
+	case "hardBreak":
+		b.WriteString("\n")
+	}
+	for _, child := range n.Content {
+		child.writeText(b)
+	}
+	switch n.Type {
+	case "paragraph", "heading", "listItem", "codeBlock", "blockquote":
+		b.WriteString("\n")
+	}
+}
+
+// exportJiraJSON writes the cards as Jira REST issues ({"issues": [...]})
+func exportJiraJSON(w io.Writer, board *Board, mapping *JiraMapping) error {
+	doc := struct {
+		Issues []jiraIssue `json:"issues"`
+	}{Issues: []jiraIssue{}}
+
+	for _, card := range board.Cards {
+		issue := jiraIssue{
+			Key: jiraCardKey(card),
+			Fields: jiraFields{
+				Summary: card.Title,
+				Status:  &jiraNamed{Name: mapping.status(card.Column)},
+				Labels:  card.Tags,
+				DueDate: card.DueDate,
+			},
+		}
+		if issue.Fields.Labels == nil {
+			issue.Fields.Labels = []string{}
+		}
+		if card.Description != "" {
+			issue.Fields.Description, _ = json.Marshal(card.Description)
+		}
+		if card.Assignee != "" {
+			issue.Fields.Assignee = &jiraUser{DisplayName: card.Assignee}
+		}
+		if !card.CreatedAt.IsZero() {
+			issue.Fields.Created = card.CreatedAt.Format(jiraTimeLayout)
+		}
+		if !card.ModifiedAt.IsZero() {
+			issue.Fields.Updated = card.ModifiedAt.Format(jiraTimeLayout)
+		}
+		doc.Issues = append(doc.Issues, issue)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// importJiraCSV reads Jira's CSV export, matching columns by header name (case-insensitive)
+// Jira repeats the Labels header once per label, so every Labels column is read
+func importJiraCSV(r io.Reader, mapping *JiraMapping) (*Board, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty CSV")
+	}
+
+	index := map[string]int{}
+	var labelColumns []int
+	for i, header := range records[0] {
+		name := strings.ToLower(strings.TrimSpace(header))
+		if name == "labels" {
+			labelColumns = append(labelColumns, i)
+		}
+		if _, seen := index[name]; !seen {
+			index[name] = i
+		}
+	}
+	if _, ok := index["summary"]; !ok {
+		return nil, fmt.Errorf("CSV has no Summary column")
+	}
+
+	field := func(row []string, name string) string {
+		if i, ok := index[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	board := &Board{}
+	for _, row := range records[1:] {
+		key := field(row, "issue key")
+		card := &Card{
+			ID:          key,
+			Title:       field(row, "summary"),
+			Description: field(row, "description"),
+			Assignee:    field(row, "assignee"),
+			DueDate:     parseJiraDueDate(field(row, "due date")),
+			Column:      mapping.column(field(row, "status")),
+			CreatedAt:   parseJiraTime(field(row, "created")),
+			ModifiedAt:  parseJiraTime(field(row, "updated")),
+			URL:         mapping.browseURL(key),
+		}
+		if card.Title == "" {
+			continue
+		}
+		for _, i := range labelColumns {
+			if i < len(row) {
+				// Labels can't contain spaces in Jira, but some exports join them in one cell
+				card.Tags = append(card.Tags, strings.Fields(row[i])...)
+			}
+		}
+		board.Cards = append(board.Cards, card)
+	}
+	return board, nil
+}
+
+// exportJiraCSV writes the cards in the layout of Jira's CSV export
+func exportJiraCSV(w io.Writer, board *Board, mapping *JiraMapping) error {
+	maxLabels := 1
+	for _, card := range board.Cards {
+		maxLabels = max(maxLabels, len(card.Tags))
+	}
+
+	headers := []string{"Issue key", "Summary", "Status", "Assignee", "Due Date", "Created", "Updated"}
+	for i := 0; i < maxLabels; i++ {
+		headers = append(headers, "Labels")
+	}
+	headers = append(headers, "Description")
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(jiraCSVTimeLayout)
+	}
+	for _, card := range board.Cards {
+		due := ""
+		if t, err := time.Parse(dueDateLayout, card.DueDate); err == nil {
+			due = t.Format(jiraCSVTimeLayout)
+		}
+		row := []string{
+			jiraCardKey(card),
+			card.Title,
+			mapping.status(card.Column),
+			card.Assignee,
+			due,
+			formatTime(card.CreatedAt),
+			formatTime(card.ModifiedAt),
+		}
+		for i := 0; i < maxLabels; i++ {
+			label := ""
+			if i < len(card.Tags) {
+				label = card.Tags[i]
+			}
+			row = append(row, label)
+		}
+		row = append(row, card.Description)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// loadJiraFixture imports a file from testdata/jira with the fixture mapping
+func loadJiraFixture(t *testing.T, name, format string) *Board {
+	t.Helper()
+	mapping, err := LoadJiraMapping(filepath.Join("testdata", "jira", "mapping.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(filepath.Join("testdata", "jira", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	board, err := ImportJira(f, format, mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return board
+}
+
+// checkJiraFixtureCards verifies the cards of the fixture exports (both formats hold the same issues)
+func checkJiraFixtureCards(t *testing.T, board *Board) {
+	t.Helper()
+	if len(board.Cards) != 3 {
+		t.Fatalf("got %d cards, want 3", len(board.Cards))
+	}
+
+	login := board.FindCard("APP-1")
+	if login == nil {
+		t.Fatal("APP-1 not imported")
+	}
+	if login.Title != "Fix login flow" || login.Column != "PROGRESS" || login.Assignee != "Alice Smith" ||
+		login.DueDate != "2025-01-15" || !slices.Equal(login.Tags, []string{"bug", "p1"}) ||
+		login.URL != "https://acme.atlassian.net/browse/APP-1" || login.CreatedAt.IsZero() {
+		t.Errorf("APP-1 = %+v", login)
+	}
+
+	dark := board.FindCard("APP-2")
+	if dark.Column != "TODO" || dark.Description != "Consider adding dark mode.\nNeeds design input." || dark.Assignee != "" {
+		t.Errorf("APP-2 = %+v", dark)
+	}
+	if ship := board.FindCard("APP-3"); ship.Column != "DONE" || ship.Assignee != "bob" {
+		t.Errorf("APP-3 = %+v", ship)
+	}
+}
+
+func TestImportJiraJSON(t *testing.T) {
+	checkJiraFixtureCards(t, loadJiraFixture(t, "search.json", FormatJiraJSON))
+}
+
+func TestImportJiraCSV(t *testing.T) {
+	checkJiraFixtureCards(t, loadJiraFixture(t, "export.csv", FormatJiraCSV))
+}
+
+func TestExportJiraRoundTrip(t *testing.T) {
+	mapping, err := LoadJiraMapping(filepath.Join("testdata", "jira", "mapping.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := loadJiraFixture(t, "search.json", FormatJiraJSON)
+
+	for _, format := range []string{FormatJiraJSON, FormatJiraCSV} {
+		var buf bytes.Buffer
+		if err := ExportJira(&buf, board, format, mapping); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		again, err := ImportJira(&buf, format, mapping)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		checkJiraFixtureCards(t, again)
+	}
+}
+
+func TestJiraMappingStatuses(t *testing.T) {
+	mapping, err := LoadJiraMapping(filepath.Join("testdata", "jira", "mapping.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for status, column := range map[string]string{"in progress": "PROGRESS", "Code Review": "REVIEW", "Blocked": "BLOCKED"} {
+		if got := mapping.column(status); got != column {
+			t.Errorf("column(%q) = %q, want %q", status, got, column)
+		}
+	}
+	if got := mapping.status("REVIEW"); got != "Code Review" {
+		t.Errorf("status(REVIEW) = %q", got)
+	}
+
+	if _, err := LoadJiraMapping(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("a missing --jira-mapping file should be an error")
+	}
+}
+
+func TestImportJiraTwiceUpdatesCards(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tkan.yaml")
+	if err := SaveBoard(path, CreateDefaultBoard()); err != nil {
+		t.Fatal(err)
+	}
+	backend := NewLocalBackend(path)
+	before, _ := backend.LoadBoard()
+
+	for run := 1; run <= 2; run++ {
+		imported := loadJiraFixture(t, "search.json", FormatJiraJSON)
+		result, err := importCards(backend, imported.Cards, false)
+		if err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+		if run == 1 && result.Created != 3 || run == 2 && (result.Created != 0 || result.Updated != 3) {
+			t.Errorf("run %d: %+v", run, result)
+		}
+	}
+
+	after, _ := backend.LoadBoard()
+	if len(after.Cards) != len(before.Cards)+3 {
+		t.Errorf("board has %d cards, want %d", len(after.Cards), len(before.Cards)+3)
+	}
+}
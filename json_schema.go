@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// JSONSchemaVersion is the version of tkan's machine-readable JSON format
+// Bump it whenever a field is removed or changes meaning (adding fields is compatible)
+// See docs/reference/JSON_SCHEMA.md
+const JSONSchemaVersion = 1
+
+// BoardJSON is the versioned JSON representation of a board
+type BoardJSON struct {
+	SchemaVersion int          `json:"schema_version"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description,omitempty"`
+	URL           string       `json:"url,omitempty"`
+	Columns       []ColumnJSON `json:"columns"`
+	Cards         []CardJSON   `json:"cards"`
+	CreatedAt     time.Time    `json:"created_at"`
+	ModifiedAt    time.Time    `json:"modified_at"`
+}
+
+// ColumnJSON is the JSON representation of a column
+type ColumnJSON struct {
+	Name      string `json:"name"`
+	Index     int    `json:"index"`
+	CardCount int    `json:"card_count"`
+}
+
+// CardJSON is the JSON representation of a card
+// Fields after Column are computed on export and ignored on import
+type CardJSON struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Tags        []string  `json:"tags"`
+	Assignee    string    `json:"assignee"`
+	DueDate     string    `json:"due_date"`
+	URL         string    `json:"url"`
+	Column      string    `json:"column"`
+	CreatedAt   time.Time `json:"created_at"`
+	ModifiedAt  time.Time `json:"modified_at"`
+
+	// Computed fields
+	ColumnIndex  int    `json:"column_index"`             // Index of Column in the board (-1 if unknown)
+	Position     int    `json:"position"`                 // Index of the card within its column
+	DueStatus    string `json:"due_status"`               // none, later, soon or overdue
+	DaysUntilDue *int   `json:"days_until_due,omitempty"` // Negative when overdue; absent without a valid due date
+}
+
+// CardDocumentJSON wraps a single card with the schema version
+type CardDocumentJSON struct {
+	SchemaVersion int      `json:"schema_version"`
+	Card          CardJSON `json:"card"`
+}
+
+// BoardToJSON converts a board (or a subset of its cards) to the JSON representation
+// If cards is nil, all of the board's cards are included
+func BoardToJSON(board *Board, cards []*Card, now time.Time) BoardJSON {
+	if cards == nil {
+		cards = board.Cards
+	}
+
+	out := BoardJSON{
+		SchemaVersion: JSONSchemaVersion,
+		Name:          board.Name,
+		Description:   board.Description,
+		URL:           board.URL,
+		Columns:       make([]ColumnJSON, 0, len(board.Columns)),
+		Cards:         make([]CardJSON, 0, len(cards)),
+		CreatedAt:     board.CreatedAt,
+		ModifiedAt:    board.ModifiedAt,
+	}
+
+	for i, col := range board.Columns {
+		out.Columns = append(out.Columns, ColumnJSON{
+			Name:      col.Name,
+			Index:     i,
+			CardCount: len(col.Cards),
+		})
+	}
+	for _, card := range cards {
+		out.Cards = append(out.Cards, CardToJSON(board, card, now))
+	}
+
+	return out
+}
+
+// CardToJSON converts a card to the JSON representation, filling in computed fields
+func CardToJSON(board *Board, card *Card, now time.Time) CardJSON {
+	out := CardJSON{
+		ID:          card.ID,
+		Title:       card.Title,
+		Description: card.Description,
+		Tags:        card.Tags,
+		Assignee:    card.Assignee,
+		DueDate:     card.DueDate,
+		URL:         card.URL,
+		Column:      card.Column,
+		CreatedAt:   card.CreatedAt,
+		ModifiedAt:  card.ModifiedAt,
+		ColumnIndex: -1,
+		Position:    -1,
+		DueStatus:   card.DueStatusAt(now, defaultDueSoonDays).String(),
+	}
+	if out.Tags == nil {
+		out.Tags = []string{}
+	}
+
+	for i, col := range board.Columns {
+		if col.Name != card.Column {
+			continue
+		}
+		out.ColumnIndex = i
+		for j, c := range col.Cards {
+			if c == card {
+				out.Position = j
+				break
+			}
+		}
+		break
+	}
+
+	if due, ok := card.Due(); ok {
+		days := daysUntil(due, now)
+		out.DaysUntilDue = &days
+	}
+
+	return out
+}
+
+// ToCard converts the JSON representation back to a card (computed fields are dropped)
+func (c CardJSON) ToCard() *Card {
+	return &Card{
+		ID:          c.ID,
+		Title:       c.Title,
+		Description: c.Description,
+		Tags:        c.Tags,
+		Assignee:    c.Assignee,
+		DueDate:     c.DueDate,
+		URL:         c.URL,
+		Column:      c.Column,
+		CreatedAt:   c.CreatedAt,
+		ModifiedAt:  c.ModifiedAt,
+	}
+}
+
+// DecodeCardsJSON reads cards for import from a board document, a card
+// document or a bare array of cards
+func DecodeCardsJSON(r io.Reader) ([]CardJSON, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON: %w", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no JSON input")
+	}
+
+	// Bare array of cards
+	if data[0] == '[' {
+		var cards []CardJSON
+		if err := json.Unmarshal(data, &cards); err != nil {
+			return nil, fmt.Errorf("failed to parse card list: %w", err)
+		}
+		return cards, nil
+	}
+
+	var doc struct {
+		SchemaVersion int        `json:"schema_version"`
+		Cards         []CardJSON `json:"cards"`
+		Card          *CardJSON  `json:"card"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON document: %w", err)
+	}
+	if doc.SchemaVersion > JSONSchemaVersion {
+		return nil, fmt.Errorf("unsupported schema_version %d (this tkan supports up to %d)", doc.SchemaVersion, JSONSchemaVersion)
+	}
+
+	if doc.Card != nil {
+		return append(doc.Cards, *doc.Card), nil
+	}
+	return doc.Cards, nil
+}
@@ -4,17 +4,29 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"strconv"
-	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
+	// Run a CLI subcommand (e.g. `tkan due`) instead of the TUI if one was given
+	if len(os.Args) > 1 {
+		if code, ok := runSubcommand(os.Args[1], os.Args[2:]); ok {
+			os.Exit(code)
+		}
+	}
+
 	// Parse command-line flags
 	var (
 		githubProject = flag.String("github", "", "Use GitHub Project (format: owner/project-number or owner/repo/project-number)")
 		githubOwner   = flag.String("github-owner", "", "List all GitHub Projects from owner (use @me for your own projects)")
+		githubHost    = flag.String("github-host", "", "GitHub Enterprise host (default github.com, or github_host in ~/.config/tkan/config.yaml)")
+		githubIssues  = flag.Bool("github-issues", false, "Create new cards as issues in the project's repository (or the repo in owner/repo/project-number)")
+		giteaProject  = flag.String("gitea", "", "Use a Gitea/Forgejo project (format: host/owner/repo/project-id)")
+		gitlabBoard   = flag.String("gitlab", "", "Use a GitLab issue board (format: host/group/project/board-id)")
+		gitlabDelete  = flag.Bool("gitlab-delete-issues", false, "Delete GitLab issues when their cards are deleted (default: close them)")
+		refresh       = flag.String("refresh", "", "How often to re-fetch remote boards, e.g. 30s (default 1m, or refresh_interval in ~/.config/tkan/config.yaml; 0 disables)")
 		help          = flag.Bool("help", false, "Show help")
 	)
 	flag.Parse()
@@ -25,23 +37,43 @@ func main() {
 		fmt.Println("  tkan                       # Use local .tkan.yaml files")
 		fmt.Println("  tkan --github owner/1      # Use GitHub Project #1 from owner")
 		fmt.Println("  tkan --github owner/repo/1 # Use GitHub Project #1 from owner/repo")
+		fmt.Println("  tkan --github owner/repo/1 --github-issues  # New cards become issues in owner/repo")
 		fmt.Println("  tkan --github-owner owner  # List all GitHub projects from owner")
 		fmt.Println("  tkan --github-owner @me    # List all your GitHub projects")
+		fmt.Println("  tkan --github-host ghe.example.com --github owner/1  # GitHub Enterprise Server")
+		fmt.Println("  tkan --github owner/1 --refresh 30s  # Re-fetch the board every 30 seconds")
+		fmt.Println("  tkan --gitea git.example.com/owner/repo/3  # Use a Gitea/Forgejo project")
+		fmt.Println("  tkan --gitlab gitlab.com/group/project/5   # Use a GitLab issue board")
 		fmt.Println("\nExamples:")
 		fmt.Println("  tkan --github matt/1")
 		fmt.Println("  tkan --github microsoft/vscode/2")
 		fmt.Println("  tkan --github-owner @me")
 		fmt.Println("  tkan --github-owner GGPrompts")
+		printSubcommandUsage()
 		os.Exit(0)
 	}
 
+	refreshEvery, err := ResolveRefreshInterval(*refresh)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	// Changes to GitHub boards made while offline are queued here
+	outbox, err := OpenOutbox()
+	if err != nil {
+		fmt.Printf("Warning: offline queue disabled: %v\n", err)
+	}
+
 	var backend Backend
 	var board *Board
+	var lastSynced time.Time // Set for remote boards
+	var refreshing bool      // Board came from the cache and needs a refresh
 	var projects []Project
 
 	if *githubOwner != "" {
 		// List all GitHub projects from owner
-		ghProjects, err := ListGitHubProjects(*githubOwner)
+		ghProjects, err := ListGitHubProjects(ResolveGitHubHost(*githubHost, ""), *githubOwner)
 		if err != nil {
 			fmt.Printf("Error listing GitHub projects: %v\n", err)
 			fmt.Printf("\nMake sure you have:\n")
@@ -77,36 +109,28 @@ func main() {
 
 	} else if *githubProject != "" {
 		// Parse GitHub project specification
-		parts := strings.Split(*githubProject, "/")
-		if len(parts) < 2 || len(parts) > 3 {
-			fmt.Println("Invalid GitHub project format. Use: owner/project-number or owner/repo/project-number")
-			os.Exit(1)
-		}
-
-		owner := parts[0]
-		var repoName string
-		var projectNumStr string
-
-		if len(parts) == 2 {
-			// Format: owner/project-number
-			projectNumStr = parts[1]
-		} else {
-			// Format: owner/repo/project-number
-			repoName = parts[1]
-			projectNumStr = parts[2]
-		}
-
-		projectNum, err := strconv.Atoi(projectNumStr)
+		owner, repoName, projectNum, err := ParseGitHubProjectSpec(*githubProject)
 		if err != nil {
-			fmt.Printf("Invalid project number: %s\n", projectNumStr)
+			fmt.Println(err)
 			os.Exit(1)
 		}
 
 		// Create GitHub backend
-		backend = NewGitHubBackend(owner, projectNum, repoName)
+		gh := NewGitHubBackend(ResolveGitHubHost(*githubHost, *githubProject), owner, projectNum, repoName)
+		gh.createAsIssues = *githubIssues
+		gh.outbox = outbox
+		gh.cacheBoards = true
+		backend = gh
 		
-		// Load board from GitHub
-		board, err = backend.LoadBoard()
+		// Open instantly from the cache when possible; the TUI refreshes it in the background
+		if cached, synced, cacheErr := gh.CachedBoard(); cacheErr == nil {
+			board = cached
+			lastSynced = synced
+			refreshing = true
+		} else {
+			board, err = backend.LoadBoard()
+			lastSynced = time.Now()
+		}
 		if err != nil {
 			fmt.Printf("Error loading GitHub project: %v\n", err)
 			fmt.Printf("\nMake sure you have:\n")
@@ -122,6 +146,50 @@ func main() {
 			Path: fmt.Sprintf("github:%s", *githubProject),
 			Dir:  "GitHub",
 		}}
+	} else if *giteaProject != "" {
+		host, owner, repo, projectID, err := ParseGiteaProjectSpec(*giteaProject)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		backend = NewGiteaBackend(host, owner, repo, projectID)
+		board, err = backend.LoadBoard()
+		if err != nil {
+			fmt.Printf("Error loading Gitea project: %v\n", err)
+			fmt.Printf("\nMake sure GITEA_TOKEN (or FORGEJO_TOKEN) holds an access token for %s\n", host)
+			os.Exit(1)
+		}
+		lastSynced = time.Now()
+
+		projects = []Project{{
+			Name: fmt.Sprintf("Gitea: %s", board.Name),
+			Path: fmt.Sprintf("gitea:%s", *giteaProject),
+			Dir:  "Gitea",
+		}}
+	} else if *gitlabBoard != "" {
+		host, project, boardID, err := ParseGitLabBoardSpec(*gitlabBoard)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		gl := NewGitLabBackend(host, project, boardID)
+		gl.deleteIssues = *gitlabDelete
+		backend = gl
+		board, err = backend.LoadBoard()
+		if err != nil {
+			fmt.Printf("Error loading GitLab board: %v\n", err)
+			fmt.Printf("\nMake sure GITLAB_TOKEN holds an access token with the api scope for %s\n", host)
+			os.Exit(1)
+		}
+		lastSynced = time.Now()
+
+		projects = []Project{{
+			Name: fmt.Sprintf("GitLab: %s", board.Name),
+			Path: fmt.Sprintf("gitlab:%s", *gitlabBoard),
+			Dir:  "GitLab",
+		}}
 	} else {
 		// Use local backend
 		cwd, err := os.Getwd()
@@ -153,7 +221,7 @@ func main() {
 		}
 
 		// Create local backend
-		backend = NewLocalBackend(projects[0].Path)
+		backend = NewFileBackend(projects[0].Path)
 
 		// Load the first project by default
 		board, err = backend.LoadBoard()
@@ -161,10 +229,20 @@ func main() {
 			fmt.Printf("Error loading board: %v\n", err)
 			os.Exit(1)
 		}
+
+		// Generate any recurring cards that came due since the last run
+		if _, err := tickBoard(backend, board, time.Now()); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
 	}
 
 	// Initialize model with backend
 	m := NewModelWithBackend(board, projects, backend)
+	m.githubHost = *githubHost
+	m.outbox = outbox
+	m.lastSynced = lastSynced
+	m.refreshing = refreshing
+	m.refreshEvery = refreshEvery
 
 	// Create Bubbletea program
 	p := tea.NewProgram(
@@ -2,6 +2,10 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,9 +17,9 @@ import (
 // NewModel creates a new Model with the given board and projects
 func NewModel(board *Board, projects []Project) Model {
 	// Default to local backend for backward compatibility
-	backend := NewLocalBackend(".tkan.yaml")
+	var backend Backend = NewLocalBackend(".tkan.yaml")
 	if len(projects) > 0 {
-		backend = NewLocalBackend(projects[0].Path)
+		backend = NewFileBackend(projects[0].Path)
 	}
 	return NewModelWithBackend(board, projects, backend)
 }
@@ -39,6 +43,7 @@ func NewModelWithBackend(board *Board, projects []Project, backend Backend) Mode
 		selectedCard:     0,
 		showDetails:      true,  // Start with details panel visible
 		showArchive:      false, // Hide archive by default
+		dueSoonDays:      defaultDueSoonDays,
 		width:            0,
 		height:           0,
 		ready:            false,
@@ -49,7 +54,15 @@ func NewModelWithBackend(board *Board, projects []Project, backend Backend) Mode
 
 // Init initializes the model (required by Bubbletea)
 func (m Model) Init() tea.Cmd {
-	return nil
+	cmds := []tea.Cmd{outboxTickCmd(), syncClockCmd()}
+	if m.refreshEvery > 0 {
+		cmds = append(cmds, refreshTickCmd(m.refreshEvery))
+	}
+	if m.refreshing {
+		// The board came from the local cache; fetch the current one
+		cmds = append(cmds, loadBoardCmd(m.backend))
+	}
+	return tea.Batch(cmds...)
 }
 
 // setSize updates the model dimensions and recalculates layout
@@ -133,6 +146,11 @@ func (m *Model) moveSelectionDown() {
 	}
 }
 
+// cardDueStatus returns the due date status of a card as of now
+func (m Model) cardDueStatus(card *Card) DueStatus {
+	return card.DueStatusAt(time.Now(), m.dueSoonDays)
+}
+
 // toggleDetails toggles the visibility of the detail panel
 func (m *Model) toggleDetails() {
 	m.showDetails = !m.showDetails
@@ -161,51 +179,109 @@ func (m Model) getVisibleColumns() []Column {
 }
 
 // loadSelectedProject loads the currently selected project
-func (m *Model) loadSelectedProject() error {
+// Returns a command that refreshes the board when it was shown from the cache
+func (m *Model) loadSelectedProject() (tea.Cmd, error) {
 	if m.selectedProject < 0 || m.selectedProject >= len(m.projects) {
-		return nil
+		return nil, nil
 	}
 
 	project := m.projects[m.selectedProject]
 
-	var board *Board
-	var err error
+	// A refresh of the previous project is dropped when it arrives (see boardLoadedMsg)
+	m.refreshing = false
+
+	var backend Backend
+	remote := true
 
 	// Check if this is a GitHub project
 	if strings.HasPrefix(project.Path, "github:") {
-		// Parse GitHub project path: github:owner/project-number
-		pathParts := strings.TrimPrefix(project.Path, "github:")
-		parts := strings.Split(pathParts, "/")
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid GitHub project path: %s", project.Path)
+		// Parse GitHub project path: github:owner/project-number or github:owner/repo/project-number
+		owner, repoName, projectNum, err := ParseGitHubProjectSpec(strings.TrimPrefix(project.Path, "github:"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub project path %s: %w", project.Path, err)
 		}
 
-		owner := parts[0]
-		projectNum := 0
-		if _, err := fmt.Sscanf(parts[1], "%d", &projectNum); err != nil {
-			return fmt.Errorf("invalid project number in path: %s", parts[1])
+		// Create new GitHub backend, keeping the create-as-issues option when switching projects
+		spec := strings.TrimPrefix(project.Path, "github:")
+		gh := NewGitHubBackend(ResolveGitHubHost(m.githubHost, spec), owner, projectNum, repoName)
+		if prev, ok := m.backend.(*GitHubBackend); ok {
+			gh.createAsIssues = prev.createAsIssues
 		}
-
-		// Create new GitHub backend
-		m.backend = NewGitHubBackend(owner, projectNum, "")
-		board, err = m.backend.LoadBoard()
+		gh.outbox = m.outbox
+		gh.cacheBoards = true
+
+		// Show the cached board right away and refresh it in the background
+		if cached, synced, err := gh.CachedBoard(); err == nil {
+			m.setBackend(gh)
+			m.showBoard(cached)
+			m.lastSynced = synced
+			m.refreshing = true
+			return loadBoardCmd(m.backend), nil
+		}
+		backend = gh
+	} else if strings.HasPrefix(project.Path, "gitea:") {
+		host, owner, repo, projectID, err := ParseGiteaProjectSpec(strings.TrimPrefix(project.Path, "gitea:"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Gitea project path %s: %w", project.Path, err)
+		}
+		backend = NewGiteaBackend(host, owner, repo, projectID)
+	} else if strings.HasPrefix(project.Path, "gitlab:") {
+		host, path, boardID, err := ParseGitLabBoardSpec(strings.TrimPrefix(project.Path, "gitlab:"))
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("invalid GitLab board path %s: %w", project.Path, err)
 		}
+		gl := NewGitLabBackend(host, path, boardID)
+		if prev, ok := m.backend.(*GitLabBackend); ok {
+			gl.deleteIssues = prev.deleteIssues
+		}
+		backend = gl
 	} else {
 		// Local YAML project
-		m.backend = NewLocalBackend(project.Path)
-		board, err = m.backend.LoadBoard()
-		if err != nil {
-			return err
+		backend = NewFileBackend(project.Path)
+		remote = false
+	}
+
+	// Only switch once the board has loaded, so a failure leaves the open board usable
+	board, err := backend.LoadBoard()
+	if err != nil {
+		closeBackend(backend)
+		return nil, err
+	}
+	m.setBackend(backend)
+
+	if remote {
+		m.lastSynced = time.Now()
+	} else {
+		m.lastSynced = time.Time{}
+
+		// Generate any recurring cards that came due since the last run
+		if _, err := tickBoard(m.backend, board, time.Now()); err != nil {
+			m.statusMessage = fmt.Sprintf("Recurring cards: %v", err)
+		}
+	}
+
+	m.showBoard(board)
+	return nil, nil
+}
+
+// setBackend switches to another backend, closing the previous one if it holds
+// a connection (such as a SQLite database)
+func (m *Model) setBackend(backend Backend) {
+	if m.backend != backend {
+		if closer, ok := m.backend.(io.Closer); ok {
+			closer.Close()
 		}
+		m.deletedCards = nil
 	}
+	m.backend = backend
+}
 
+// showBoard switches to the board view of a newly opened board
+func (m *Model) showBoard(board *Board) {
 	m.board = board
 	m.viewMode = ViewBoard
 	m.selectedColumn = 0
 	m.selectedCard = 0
-	return nil
 }
 
 // getColumnAtPosition determines which column is at the given screen position
@@ -402,22 +478,26 @@ func (m *Model) getInsertIndexInColumn(col Column, relY int) int {
 }
 
 // moveCard moves a card from one position to another (within or across columns)
-func (m *Model) moveCard(fromColIndex, fromCardIndex, toColIndex, insertIndex int) {
+// Returns a command that syncs the move when the backend queues moves
+func (m *Model) moveCard(fromColIndex, fromCardIndex, toColIndex, insertIndex int) tea.Cmd {
+	if m.syncBlocksEdits() {
+		return nil
+	}
 	visibleColumns := m.getVisibleColumns()
 
 	// Validate indices
 	if fromColIndex < 0 || fromColIndex >= len(visibleColumns) {
-		return
+		return nil
 	}
 	if toColIndex < 0 || toColIndex >= len(visibleColumns) {
-		return
+		return nil
 	}
 
 	fromCol := visibleColumns[fromColIndex]
 	toCol := visibleColumns[toColIndex]
 
 	if fromCardIndex < 0 || fromCardIndex >= len(fromCol.Cards) {
-		return
+		return nil
 	}
 
 	// Get the card to move
@@ -435,14 +515,14 @@ func (m *Model) moveCard(fromColIndex, fromCardIndex, toColIndex, insertIndex in
 	}
 
 	if fromColPtr == nil || toColPtr == nil {
-		return
+		return nil
 	}
 
 	// Handle reordering within the same column
 	if fromColIndex == toColIndex {
 		// Check if actually moving to a different position
 		if fromCardIndex == insertIndex || fromCardIndex+1 == insertIndex {
-			return // No effective move
+			return nil // No effective move
 		}
 
 		// Remove card from source position
@@ -484,22 +564,77 @@ func (m *Model) moveCard(fromColIndex, fromCardIndex, toColIndex, insertIndex in
 	}
 
 	// Update modification time
+	modifiedAt := card.ModifiedAt
 	card.ModifiedAt = time.Now()
 
 	// Save changes using backend
+	var cmd tea.Cmd
 	if m.backend != nil {
+		moved := false
 		// For GitHub backend, update the card's column
-		m.backend.MoveCard(card.ID, toCol.Name)
-		// For local backend, save the entire board
-		m.backend.SaveBoard(m.board)
+		if fromColIndex != toColIndex {
+			if queuer, ok := m.backend.(MoveQueuer); ok {
+				// Sent in the background so rapid drags don't block the UI
+				send := queuer.QueueMove(card.ID, toCol.Name)
+				msg := cardMoveSyncedMsg{
+					backend:    m.backend,
+					cardID:     card.ID,
+					fromColumn: fromCol.Name,
+					fromIndex:  fromCardIndex,
+					toColumn:   toCol.Name,
+					modifiedAt: modifiedAt,
+				}
+				cmd = func() tea.Msg {
+					msg.err = send()
+					return msg
+				}
+				moved = true
+			} else if err := m.backend.MoveCard(card.ID, toCol.Name); err != nil {
+				// Put the card back, so the board shows what the backend has
+				toColPtr.Cards = slices.DeleteFunc(toColPtr.Cards, func(c *Card) bool { return c == card })
+				fromColPtr.Cards = slices.Insert(fromColPtr.Cards, fromCardIndex, card)
+				card.Column, card.ModifiedAt = fromCol.Name, modifiedAt
+				m.selectedColumn, m.selectedCard = fromColIndex, fromCardIndex
+				m.statusMessage = fmt.Sprintf("Move failed: %v", err)
+				return nil
+			} else {
+				moved = true
+			}
+		}
+		// The backend has the new column; the whole board is only saved when the
+		// card was put somewhere in the column that reloading wouldn't put it
+		if !moved || !m.board.InBoardOrder(toColPtr) {
+			if err := m.backend.SaveBoard(m.board); err != nil {
+				m.statusMessage = fmt.Sprintf("Save failed: %v", err)
+			}
+		}
 	}
+	return cmd
 }
 
 // openCreateCardForm opens the form for creating a new card
+// If any templates are available, the template picker is shown first
 func (m *Model) openCreateCardForm() {
+	if m.syncBlocksEdits() {
+		return
+	}
+	m.templates = AvailableTemplates(m.board)
+	if len(m.templates) > 0 {
+		m.formMode = FormPickTemplate
+		m.selectedTemplate = 0
+		return
+	}
+
+	m.openCreateCardFormFromTemplate(nil)
+}
+
+// openCreateCardFormFromTemplate opens the create form, pre-filled from tmpl (nil for a blank card)
+func (m *Model) openCreateCardFormFromTemplate(tmpl *CardTemplate) {
 	m.formMode = FormCreateCard
 	m.editingCardID = ""
 	m.formFocusIndex = 0
+	m.formError = ""
+	m.formTemplate = tmpl
 
 	// Create text inputs
 	titleInput := textinput.New()
@@ -513,11 +648,37 @@ func (m *Model) openCreateCardForm() {
 	descInput.CharLimit = 500
 	descInput.Width = 60
 
-	m.formInputs = []textinput.Model{titleInput, descInput}
+	dueInput := textinput.New()
+	dueInput.Placeholder = "Due date YYYY-MM-DD (optional)"
+	dueInput.CharLimit = 30
+	dueInput.Width = 60
+
+	// Pre-fill from the template
+	if tmpl != nil {
+		card := tmpl.Instantiate(time.Now())
+		titleInput.SetValue(card.Title)
+		descInput.SetValue(card.Description)
+		dueInput.SetValue(card.DueDate)
+	}
+
+	m.formInputs = []textinput.Model{titleInput, descInput, dueInput}
+}
+
+// selectTemplate opens the create form for the template chosen in the picker
+func (m *Model) selectTemplate() {
+	if m.selectedTemplate > 0 && m.selectedTemplate <= len(m.templates) {
+		tmpl := m.templates[m.selectedTemplate-1]
+		m.openCreateCardFormFromTemplate(&tmpl)
+		return
+	}
+	m.openCreateCardFormFromTemplate(nil)
 }
 
 // openEditCardForm opens the form for editing an existing card
 func (m *Model) openEditCardForm() {
+	if m.syncBlocksEdits() {
+		return
+	}
 	var card *Card
 
 	// Get card based on current view
@@ -534,6 +695,7 @@ func (m *Model) openEditCardForm() {
 	m.formMode = FormEditCard
 	m.editingCardID = card.ID
 	m.formFocusIndex = 0
+	m.formError = ""
 
 	// Create text inputs pre-filled with card data
 	titleInput := textinput.New()
@@ -549,7 +711,13 @@ func (m *Model) openEditCardForm() {
 	descInput.CharLimit = 500
 	descInput.Width = 60
 
-	m.formInputs = []textinput.Model{titleInput, descInput}
+	dueInput := textinput.New()
+	dueInput.Placeholder = "Due date YYYY-MM-DD (optional)"
+	dueInput.SetValue(card.DueDate)
+	dueInput.CharLimit = 30
+	dueInput.Width = 60
+
+	m.formInputs = []textinput.Model{titleInput, descInput, dueInput}
 }
 
 // closeCardForm closes the card form without saving
@@ -557,12 +725,21 @@ func (m *Model) closeCardForm() {
 	m.formMode = FormNone
 	m.formInputs = nil
 	m.editingCardID = ""
+	m.formError = ""
+	m.formSaving = false
+	m.formTemplate = nil
 }
 
 // saveCardForm saves the card form (create or edit)
-func (m *Model) saveCardForm() {
-	if len(m.formInputs) < 2 {
-		return
+// Remote backends can retry and wait out rate limits, so the save runs in the
+// background and the form stays open until handleCardSaved
+func (m *Model) saveCardForm() tea.Cmd {
+	if len(m.formInputs) < 3 || m.formSaving {
+		return nil
+	}
+	if m.syncing {
+		m.formError = "Sync in progress; save again once it finishes"
+		return nil
 	}
 
 	title := m.formInputs[0].Value()
@@ -570,7 +747,15 @@ func (m *Model) saveCardForm() {
 
 	// Title is required
 	if title == "" {
-		return
+		m.formError = "Title is required"
+		return nil
+	}
+
+	// Due date must be a recognizable date (stored as YYYY-MM-DD)
+	dueDate, err := NormalizeDueDate(m.formInputs[2].Value())
+	if err != nil {
+		m.formError = err.Error()
+		return nil
 	}
 
 	if m.formMode == FormCreateCard {
@@ -578,7 +763,7 @@ func (m *Model) saveCardForm() {
 		col := m.getCurrentColumn()
 		if col == nil {
 			m.closeCardForm()
-			return
+			return nil
 		}
 
 		// Find the actual column in the board
@@ -592,103 +777,453 @@ func (m *Model) saveCardForm() {
 
 		if colPtr == nil {
 			m.closeCardForm()
-			return
+			return nil
 		}
 
-		// Generate ID
-		id := generateCardID()
-
-		// Create card
-		now := time.Now()
-		newCard := &Card{
-			ID:          id,
-			Title:       title,
-			Description: description,
-			Column:      col.Name,
-			CreatedAt:   now,
-			ModifiedAt:  now,
+		// Fields that CreateCard doesn't take
+		fields := Card{DueDate: dueDate}
+		if m.formTemplate != nil {
+			fields.Tags = append([]string(nil), m.formTemplate.Tags...)
+			fields.Assignee = m.formTemplate.Assignee
 		}
 
-		// Add to column and board
-		colPtr.Cards = append(colPtr.Cards, newCard)
-		m.board.Cards = append(m.board.Cards, newCard)
-
-		// Select the new card
-		m.selectedCard = len(colPtr.Cards) - 1
+		if m.backend == nil {
+			now := time.Now()
+			fields.ID = m.board.NextCardID()
+			fields.Title = title
+			fields.Description = description
+			fields.Column = colPtr.Name
+			fields.CreatedAt = now
+			fields.ModifiedAt = now
+			m.applySavedCard(&fields, true)
+			m.closeCardForm()
+			return nil
+		}
+		m.formSaving = true
+		return createCardCmd(m.backend, title, description, colPtr.Name, fields)
 
 	} else if m.formMode == FormEditCard {
 		// Edit existing card
-		for _, card := range m.board.Cards {
-			if card.ID == m.editingCardID {
-				card.Title = title
-				card.Description = description
-				card.ModifiedAt = time.Now()
+		card := m.board.FindCard(m.editingCardID)
+		if card == nil {
+			m.closeCardForm()
+			return nil
+		}
+
+		// Send the edited copy to the backend, then apply it locally
+		updated := *card
+		updated.Title = title
+		updated.Description = description
+		updated.DueDate = dueDate
+		updated.ModifiedAt = time.Now()
+
+		if m.backend == nil {
+			m.applySavedCard(&updated, false)
+			m.closeCardForm()
+			return nil
+		}
+		m.formSaving = true
+		return updateCardCmd(m.backend, updated)
+	}
+	return nil
+}
+
+// applySavedCard puts a created or edited card on the board
+func (m *Model) applySavedCard(card *Card, created bool) {
+	if created {
+		m.board.AddCard(card)
+	} else if existing := m.board.FindCard(card.ID); existing != nil {
+		*existing = *card
+	}
+
+	// Rebuild table if in table view
+	if m.viewMode == ViewTable {
+		m.buildTable()
+	}
+
+	// Select the new card
+	if created {
+		m.selectCardByID(card.ID)
+	}
+}
+
+// createCardCmd creates a card through the backend in the background
+// Tags, assignee and due date aren't part of Backend.CreateCard, so they are set with a follow-up UpdateCard
+func createCardCmd(backend Backend, title, description, column string, fields Card) tea.Cmd {
+	return func() tea.Msg {
+		card, err := backend.CreateCard(title, description, column)
+		if err != nil {
+			if card == nil {
+				return cardSavedMsg{backend: backend, created: true, err: err}
+			}
+			// The card exists but wasn't fully set up (e.g. its column); keep it and report
+			return cardSavedMsg{backend: backend, card: card, created: true, warning: err.Error()}
+		}
+
+		msg := cardSavedMsg{backend: backend, card: card, created: true}
+		if len(fields.Tags) > 0 || fields.Assignee != "" || fields.DueDate != "" {
+			card.Tags = fields.Tags
+			card.Assignee = fields.Assignee
+			card.DueDate = fields.DueDate
+			if err := backend.UpdateCard(card); err != nil {
+				// The card exists; keep it on the board and report the partial failure
+				msg.warning = fmt.Sprintf("Card created but some fields were not saved: %v", err)
+			}
+		}
+		return msg
+	}
+}
+
+// updateCardCmd sends an edited card to the backend in the background
+func updateCardCmd(backend Backend, card Card) tea.Cmd {
+	return func() tea.Msg {
+		err := backend.UpdateCard(&card)
+		return cardSavedMsg{backend: backend, card: &card, err: err}
+	}
+}
+
+// handleCardSaved applies a saved card, or shows why the save failed in the form
+func (m Model) handleCardSaved(msg cardSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.backend != m.backend {
+		return m, nil // The user switched projects while the save was in flight
+	}
+	// The form may have been cancelled while the save was in flight
+	waiting := m.formSaving
+	m.formSaving = false
+
+	if msg.err != nil {
+		verb := "Update"
+		if msg.created {
+			verb = "Create"
+		}
+		if waiting {
+			m.formError = fmt.Sprintf("%s failed: %v", verb, msg.err)
+		} else {
+			m.statusMessage = fmt.Sprintf("%s failed: %v", verb, msg.err)
+		}
+		return m, nil
+	}
+
+	m.applySavedCard(msg.card, msg.created)
+	if waiting {
+		m.closeCardForm()
+	}
+	if msg.warning != "" {
+		m.statusMessage = msg.warning
+	}
+	return m, nil
+}
+
+// applyOutboxReplay gives cards created offline their remote IDs and reports the replay
+func (m *Model) applyOutboxReplay(result OutboxReplayResult) {
+	for localID, created := range result.Created {
+		if card := m.board.FindCard(localID); card != nil {
+			card.ID = created.ID
+			card.ContentType = created.ContentType
+			card.ContentID = created.ContentID
+			card.URL = created.URL
+		}
+	}
+
+	switch {
+	case len(result.Failed) > 0:
+		m.statusMessage = fmt.Sprintf("Sync failed for %d offline change(s): %v", len(result.Failed), result.Failed[0])
+	case result.Sent > 0:
+		m.statusMessage = fmt.Sprintf("Synced %d offline change(s)", result.Sent)
+	}
+	if m.viewMode == ViewTable && m.table != nil {
+		m.buildTable()
+	}
+}
+
+// applyRemoteBoard merges a freshly loaded remote board into the current one,
+// keeping the selected card selected, and returns what changed
+func (m *Model) applyRemoteBoard(remote *Board, started time.Time) BoardDiff {
+	var selectedID string
+	if m.viewMode == ViewTable {
+		if card := m.getSelectedCardInTable(); card != nil {
+			selectedID = card.ID
+		}
+	} else if card := m.getCurrentCard(); card != nil {
+		selectedID = card.ID
+	}
+
+	merged := MergeRemoteBoard(m.board, remote, started, m.deletedCards)
+	diff := DiffBoards(m.board, merged)
+	// Later refreshes start after these deletes finished, so they won't see the cards
+	for id, at := range m.deletedCards {
+		if !at.After(started) {
+			delete(m.deletedCards, id)
+		}
+	}
+	m.board = merged
+	m.lastSynced = time.Now()
+
+	if m.viewMode == ViewTable && m.table != nil {
+		m.buildTable()
+	}
+	m.selectCardByID(selectedID)
+	return diff
+}
+
+// highlightRemoteChanges marks cards added or changed by a refresh for a few seconds,
+// skipping cards whose own changes are still queued in the outbox
+func (m *Model) highlightRemoteChanges(diff BoardDiff) {
+	now := time.Now()
+	for id, until := range m.remoteChanges {
+		if now.After(until) {
+			delete(m.remoteChanges, id)
+		}
+	}
+	if diff.Empty() {
+		return
+	}
+
+	if m.remoteChanges == nil {
+		m.remoteChanges = map[string]time.Time{}
+	}
+	pending := m.pendingCards()
+	changed := 0
+	for _, id := range append(diff.Added, diff.Changed...) {
+		if pending[id] {
+			continue // Our own queued change, not a teammate's
+		}
+		m.remoteChanges[id] = now.Add(remoteChangeHighlight)
+		changed++
+	}
+	if changed > 0 || len(diff.Removed) > 0 {
+		m.statusMessage = fmt.Sprintf("Remote changes: %d new, %d updated, %d removed",
+			len(diff.Added), len(diff.Changed), len(diff.Removed))
+	}
+}
+
+// changedRemotely reports whether a card is still highlighted after a refresh
+func (m Model) changedRemotely(card *Card) bool {
+	until, ok := m.remoteChanges[card.ID]
+	return ok && time.Now().Before(until)
+}
+
+// selectCardByID moves the board and table selection to the card with the given ID
+func (m *Model) selectCardByID(id string) {
+	if id == "" {
+		return
+	}
+
+	for colIndex, col := range m.board.Columns {
+		for cardIndex, card := range col.Cards {
+			if card.ID == id {
+				m.selectedColumn = colIndex
+				m.selectedCard = cardIndex
+			}
+		}
+	}
+
+	if m.viewMode == ViewTable && m.table != nil {
+		for i, card := range m.tableCardIndex {
+			if card.ID == id {
+				// The table can only step its cursor, so walk from wherever it is now
+				_, row := m.table.GetCursorLocation()
+				for ; row > i; row-- {
+					m.table.CursorUp()
+				}
+				for ; row < i; row++ {
+					m.table.CursorDown()
+				}
 				break
 			}
 		}
 	}
+}
 
-	// Save changes
-	if m.backend != nil {
-		m.backend.SaveBoard(m.board)
+// pendingCards returns the IDs of cards with changes not yet sent to the backend
+func (m Model) pendingCards() map[string]bool {
+	if replayer, ok := m.backend.(OutboxReplayer); ok {
+		return replayer.PendingCards()
 	}
+	return nil
+}
 
-	// Rebuild table if in table view
+// cardLabel returns the card's title, marked when it has unsent changes
+func cardLabel(card *Card, pending map[string]bool) string {
+	if pending[card.ID] {
+		return "⟳ " + card.Title
+	}
+	return card.Title
+}
+
+// askConvertCardToIssue asks to confirm converting a card to an issue, which
+// can't be undone and may publish the card in a public repository
+func (m *Model) askConvertCardToIssue(card *Card) {
+	if card == nil || m.syncBlocksEdits() {
+		return
+	}
+	if _, ok := m.backend.(IssueConverter); !ok {
+		m.statusMessage = "Converting to an issue requires a GitHub project"
+		return
+	}
+	m.convertingCardID = card.ID
+}
+
+// convertCardToIssue converts a draft card to an issue if the backend supports it
+// The conversion runs in the background; see handleCardConverted
+func (m *Model) convertCardToIssue(card *Card) tea.Cmd {
+	if card == nil || m.syncBlocksEdits() {
+		return nil
+	}
+	converter, ok := m.backend.(IssueConverter)
+	if !ok {
+		m.statusMessage = "Converting to an issue requires a GitHub project"
+		return nil
+	}
+
+	m.statusMessage = "Converting to an issue…"
+	backend, converted := m.backend, *card
+	return func() tea.Msg {
+		err := converter.ConvertToIssue(&converted)
+		return cardConvertedMsg{backend: backend, cardID: card.ID, card: converted, err: err}
+	}
+}
+
+// handleCardConverted gives a converted card its issue
+func (m Model) handleCardConverted(msg cardConvertedMsg) (tea.Model, tea.Cmd) {
+	if msg.backend != m.backend {
+		return m, nil // The user switched projects while the conversion was in flight
+	}
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Convert failed: %v", msg.err)
+		return m, nil
+	}
+
+	if card := m.board.FindCard(msg.cardID); card != nil {
+		card.ID = msg.card.ID
+		card.ContentType = msg.card.ContentType
+		card.ContentID = msg.card.ContentID
+		card.URL = msg.card.URL
+	}
 	if m.viewMode == ViewTable {
 		m.buildTable()
 	}
+	m.statusMessage = fmt.Sprintf("Converted to issue: %s", msg.card.URL)
+	return m, nil
+}
 
-	m.closeCardForm()
+// deleteCardByID deletes a card through the backend in the background, removing it
+// from the board once the backend has (see handleCardDeleted)
+func (m *Model) deleteCardByID(id string) tea.Cmd {
+	if m.syncBlocksEdits() {
+		return nil
+	}
+
+	backend := m.backend
+	return func() tea.Msg {
+		var err error
+		if backend != nil {
+			err = backend.DeleteCard(id)
+		}
+		return cardDeletedMsg{backend: backend, cardID: id, err: err}
+	}
 }
 
-// deleteCard deletes the currently selected card
-func (m *Model) deleteCard() {
-	col := m.getCurrentColumn()
-	if col == nil || m.selectedCard < 0 || m.selectedCard >= len(col.Cards) {
-		return // No card selected
+// handleCardDeleted removes a deleted card from the board, or reports why it wasn't
+func (m Model) handleCardDeleted(msg cardDeletedMsg) (tea.Model, tea.Cmd) {
+	if msg.backend != m.backend {
+		return m, nil // The user switched projects while the delete was in flight
 	}
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Delete failed: %v", msg.err)
+		return m, nil
+	}
+
+	m.removeCard(msg.cardID)
+	if m.deletedCards == nil {
+		m.deletedCards = map[string]time.Time{}
+	}
+	m.deletedCards[msg.cardID] = time.Now()
+
+	// Rebuild table if in table view
+	if m.viewMode == ViewTable {
+		m.buildTable()
+	}
+
+	// Adjust selection if in board view
+	if m.viewMode == ViewBoard {
+		col := m.getCurrentColumn()
+		if col != nil && m.selectedCard >= len(col.Cards) && m.selectedCard > 0 {
+			m.selectedCard--
+		}
+	}
+	return m, nil
+}
 
-	// Get the card before removing it (for backend deletion)
-	card := col.Cards[m.selectedCard]
+// handleCardMoveSynced reports a queued move's result, putting the card back if
+// the backend rejected it
+func (m Model) handleCardMoveSynced(msg cardMoveSyncedMsg) (tea.Model, tea.Cmd) {
+	if msg.backend != m.backend || msg.err == nil {
+		return m, nil
+	}
+	m.statusMessage = fmt.Sprintf("Move failed: %v", msg.err)
 
-	// Find the actual column in the board
-	var colPtr *Column
+	// Leave the card alone if it has been moved again since
+	card := m.board.FindCard(msg.cardID)
+	if card == nil || card.Column != msg.toColumn {
+		return m, nil
+	}
+	var fromColPtr, toColPtr *Column
 	for i := range m.board.Columns {
-		if m.board.Columns[i].Name == col.Name {
-			colPtr = &m.board.Columns[i]
-			break
+		switch m.board.Columns[i].Name {
+		case msg.fromColumn:
+			fromColPtr = &m.board.Columns[i]
+		case msg.toColumn:
+			toColPtr = &m.board.Columns[i]
 		}
 	}
+	if fromColPtr == nil || toColPtr == nil {
+		return m, nil
+	}
 
-	if colPtr == nil {
-		return
+	var selectedID string
+	if selected := m.getCurrentCard(); selected != nil {
+		selectedID = selected.ID
 	}
+	toColPtr.Cards = slices.DeleteFunc(toColPtr.Cards, func(c *Card) bool { return c == card })
+	fromColPtr.Cards = slices.Insert(fromColPtr.Cards, min(msg.fromIndex, len(fromColPtr.Cards)), card)
+	card.Column, card.ModifiedAt = msg.fromColumn, msg.modifiedAt
 
-	// Remove the card from the column
-	colPtr.Cards = append(colPtr.Cards[:m.selectedCard], colPtr.Cards[m.selectedCard+1:]...)
+	if m.viewMode == ViewTable {
+		m.buildTable()
+	}
+	m.selectCardByID(selectedID)
+	return m, nil
+}
 
+// removeCard removes a card from the board
+func (m *Model) removeCard(id string) {
 	// Remove the card from the board's card list
 	for i, c := range m.board.Cards {
-		if c.ID == card.ID {
+		if c.ID == id {
 			m.board.Cards = append(m.board.Cards[:i], m.board.Cards[i+1:]...)
 			break
 		}
 	}
 
-	// Adjust selected card index
-	if m.selectedCard >= len(colPtr.Cards) && m.selectedCard > 0 {
-		m.selectedCard--
-	}
-
-	// Save changes using backend
-	if m.backend != nil {
-		m.backend.SaveBoard(m.board)
+	// Remove from columns
+	for i := range m.board.Columns {
+		for j, c := range m.board.Columns[i].Cards {
+			if c.ID == id {
+				m.board.Columns[i].Cards = append(m.board.Columns[i].Cards[:j], m.board.Columns[i].Cards[j+1:]...)
+				break
+			}
+		}
 	}
 }
 
-// generateCardID generates a unique card ID
-func generateCardID() string {
-	return fmt.Sprintf("card_%d", time.Now().UnixNano())
+// deleteCard deletes the currently selected card
+func (m *Model) deleteCard() tea.Cmd {
+	col := m.getCurrentColumn()
+	if col == nil || m.selectedCard < 0 || m.selectedCard >= len(col.Cards) {
+		return nil // No card selected
+	}
+	return m.deleteCardByID(col.Cards[m.selectedCard].ID)
 }
 
 // handleProjectSourceSelection handles the selected project source option
@@ -726,9 +1261,15 @@ func (m *Model) loadLocalProjects() (tea.Model, tea.Cmd) {
 	// Load the first project by default
 	if len(projects) == 1 {
 		// Single project - load it directly
-		m.backend = NewLocalBackend(projects[0].Path)
-		board, err := m.backend.LoadBoard()
-		if err == nil {
+		backend := NewFileBackend(projects[0].Path)
+		board, err := backend.LoadBoard()
+		if err != nil {
+			closeBackend(backend)
+		} else {
+			m.setBackend(backend)
+			if _, err := tickBoard(m.backend, board, time.Now()); err != nil {
+				m.statusMessage = fmt.Sprintf("Recurring cards: %v", err)
+			}
 			m.board = board
 		}
 		m.viewMode = ViewBoard
@@ -742,7 +1283,7 @@ func (m *Model) loadLocalProjects() (tea.Model, tea.Cmd) {
 
 // loadGitHubProjects loads GitHub projects for the specified owner
 func (m *Model) loadGitHubProjects(owner string) (tea.Model, tea.Cmd) {
-	ghProjects, err := ListGitHubProjects(owner)
+	ghProjects, err := ListGitHubProjects(ResolveGitHubHost(m.githubHost, ""), owner)
 	if err != nil || len(ghProjects) == 0 {
 		// Failed to load or no projects - stay in source selector
 		return m, nil
@@ -797,21 +1338,30 @@ func (m *Model) buildTable() {
 		m.table.SetMinWidth([]int{20, 10, 10, 10, 10, 10})
 	}
 
+	// Order cards by due date if requested (cards without a due date go last)
+	cards := m.board.Cards
+	if m.tableSortDue {
+		cards = slices.Clone(cards)
+		slices.SortStableFunc(cards, compareDue)
+	}
+
 	// Build rows from all cards and track card index
-	rows := make([][]any, 0, len(m.board.Cards))
-	m.tableCardIndex = make([]*Card, 0, len(m.board.Cards))
+	rows := make([][]any, 0, len(cards))
+	m.tableCardIndex = make([]*Card, 0, len(cards))
+	now := time.Now()
+	pending := m.pendingCards()
 
-	for _, card := range m.board.Cards {
+	for _, card := range cards {
 		// Skip archived cards if archive is hidden
 		if !m.showArchive && card.Column == "ARCHIVE" {
 			continue
 		}
 
 		row := []any{
-			card.Title,
+			cardLabel(card, pending),
 			card.Column,
 			card.Assignee,
-			card.DueDate,
+			formatDueCell(card, now, m.dueSoonDays),
 			card.CreatedAt.Format("2006-01-02"),
 			card.ModifiedAt.Format("2006-01-02"),
 		}
@@ -829,6 +1379,45 @@ func (m *Model) buildTable() {
 	m.table.SetHeight(m.height - 10) // Leave room for title, status bars, and info box
 }
 
+// localBoardPath returns the file or directory a local backend stores its board in
+func localBoardPath(backend Backend) (string, bool) {
+	switch b := backend.(type) {
+	case *LocalBackend:
+		return b.filePath, true
+	case *MarkdownBackend:
+		return b.filePath, true
+	case *SQLiteBackend:
+		return b.filePath, true
+	case *DirectoryBackend:
+		return b.dir, true
+	}
+	return "", false
+}
+
+// exportTable writes the cards shown in the table view to a file next to the board
+func (m *Model) exportTable(format string) {
+	board := boardWithCards(m.board, m.tableCardIndex)
+
+	dir := "."
+	if boardPath, ok := localBoardPath(m.backend); ok {
+		dir = filepath.Dir(boardPath)
+	}
+	path := filepath.Join(dir, exportFileName(m.board, format, time.Now()))
+
+	f, err := os.Create(path)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Export failed: %v", err)
+		return
+	}
+	defer f.Close()
+
+	if err := ExportBoard(f, board, format); err != nil {
+		m.statusMessage = fmt.Sprintf("Export failed: %v", err)
+		return
+	}
+	m.statusMessage = fmt.Sprintf("Exported %d cards to %s", len(board.Cards), path)
+}
+
 // getSelectedCardInTable returns the currently selected card in table view
 func (m Model) getSelectedCardInTable() *Card {
 	if m.table == nil || len(m.tableCardIndex) == 0 {
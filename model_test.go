@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMoveCardRestoresCardWhenBackendFails(t *testing.T) {
+	// The board file is missing, so the backend can't move anything
+	backend := NewLocalBackend(filepath.Join(t.TempDir(), ".tkan.yaml"))
+	m := NewModelWithBackend(sqliteTestBoard(), nil, backend)
+
+	m.moveCard(0, 0, 1, 0)
+	if card := m.board.FindCard("1"); card.Column != "TODO" || len(m.board.Columns[0].Cards) != 1 || len(m.board.Columns[1].Cards) != 1 {
+		t.Errorf("card 1 = %+v, columns = %+v", card, m.board.Columns)
+	}
+	if m.statusMessage == "" {
+		t.Error("a failed move should be reported")
+	}
+}
+
+// rejectingQueuer is a backend whose queued moves are rejected when sent
+type rejectingQueuer struct {
+	*LocalBackend
+}
+
+func (rejectingQueuer) QueueMove(cardID, toColumn string) func() error {
+	return func() error { return errors.New("rejected") }
+}
+
+func TestQueuedMoveRestoresCardWhenRejected(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tkan.yaml")
+	if err := SaveBoard(path, sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModelWithBackend(sqliteTestBoard(), nil, rejectingQueuer{NewLocalBackend(path)})
+	modifiedAt := m.board.FindCard("1").ModifiedAt
+
+	cmd := m.moveCard(0, 0, 1, 0)
+	if cmd == nil || m.board.FindCard("1").Column != "PROGRESS" {
+		t.Fatal("a queued move should show at once")
+	}
+	model, _ := m.Update(cmd())
+	m = model.(Model)
+	card := m.board.FindCard("1")
+	if card.Column != "TODO" || !card.ModifiedAt.Equal(modifiedAt) || len(m.board.Columns[0].Cards) != 1 || len(m.board.Columns[1].Cards) != 1 {
+		t.Errorf("card 1 = %+v, columns = %+v", card, m.board.Columns)
+	}
+	if m.statusMessage == "" {
+		t.Error("a failed move should be reported")
+	}
+
+	// A rejection for a card that has moved on since leaves it where it is
+	card.Column = "DONE"
+	model, _ = m.Update(cardMoveSyncedMsg{backend: m.backend, cardID: "1", fromColumn: "TODO", toColumn: "PROGRESS", modifiedAt: time.Now(), err: errors.New("rejected")})
+	if got := model.(Model).board.FindCard("1").Column; got != "DONE" {
+		t.Errorf("card moved on to DONE was put back in %s", got)
+	}
+}
+
+func TestDeleteCardWaitsForBackend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tkan.yaml")
+	if err := SaveBoard(path, sqliteTestBoard()); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModelWithBackend(sqliteTestBoard(), nil, NewLocalBackend(path))
+
+	cmd := m.deleteCardByID("1")
+	if cmd == nil || m.board.FindCard("1") == nil {
+		t.Fatal("the card should stay until the backend has deleted it")
+	}
+	model, _ := m.Update(cmd())
+	if model.(Model).board.FindCard("1") != nil {
+		t.Error("deleted card is still on the board")
+	}
+	if board, _ := LoadBoard(path); board.FindCard("1") != nil {
+		t.Error("deleted card is still in the board file")
+	}
+
+	// A refresh that started before the delete still lists the card
+	m = model.(Model)
+	m.applyRemoteBoard(sqliteTestBoard(), time.Now().Add(-time.Minute))
+	if m.board.FindCard("1") != nil {
+		t.Error("a refresh from before the delete brought the card back")
+	}
+}
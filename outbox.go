@@ -0,0 +1,360 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// OutboxOp is the kind of mutation recorded in the outbox
+type OutboxOp string
+
+const (
+	OutboxMove   OutboxOp = "move"
+	OutboxUpdate OutboxOp = "update"
+	OutboxCreate OutboxOp = "create"
+)
+
+// outboxLocalIDPrefix marks IDs given to cards created while offline
+const outboxLocalIDPrefix = "local-"
+
+// OutboxEntry is a mutation waiting to be sent to a remote backend
+type OutboxEntry struct {
+	Seq      int64     `json:"seq"`   // Unique, increasing sequence number
+	Board    string    `json:"board"` // Remote board the mutation belongs to (see GitHubBackend.boardKey)
+	Op       OutboxOp  `json:"op"`
+	CardID   string    `json:"card_id"`          // Local ID (local-...) for cards created offline
+	Column   string    `json:"column,omitempty"` // Target column for move and create
+	Card     *Card     `json:"card,omitempty"`   // Card fields for update and create
+	QueuedAt time.Time `json:"queued_at"`
+}
+
+// Outbox is a persistent journal of remote mutations made while offline
+// Entries are stored one JSON object per line in ~/.config/tkan/outbox.jsonl
+// and replayed in order once the remote is reachable again
+type Outbox struct {
+	path     string
+	mu       sync.Mutex // Guards entries and the journal file
+	replayMu sync.Mutex // Allows one replay at a time
+	entries  []OutboxEntry
+	lastSeq  int64
+}
+
+// OpenOutbox opens the outbox journal in the user config directory
+func OpenOutbox() (*Outbox, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenOutboxFile(filepath.Join(dir, "outbox.jsonl"))
+}
+
+// OpenOutboxFile opens (or prepares to create) the outbox journal at path
+func OpenOutboxFile(path string) (*Outbox, error) {
+	o := &Outbox{path: path}
+	if err := o.load(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// load reads the journal from disk, replacing the in-memory entries
+// Caller must hold o.mu (or have exclusive access)
+func (o *Outbox) load() error {
+	data, err := os.ReadFile(o.path)
+	if os.IsNotExist(err) {
+		o.entries = nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read outbox: %w", err)
+	}
+
+	var entries []OutboxEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry OutboxEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("failed to parse outbox %s: %w", o.path, err)
+		}
+		entries = append(entries, entry)
+		if entry.Seq > o.lastSeq {
+			o.lastSeq = entry.Seq
+		}
+	}
+	o.entries = entries
+	return scanner.Err()
+}
+
+// write replaces the journal on disk with the in-memory entries
+// Caller must hold o.mu
+func (o *Outbox) write() error {
+	if len(o.entries) == 0 {
+		if err := os.Remove(o.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, entry := range o.entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	if err := os.MkdirAll(filepath.Dir(o.path), 0755); err != nil {
+		return err
+	}
+	tmp := o.path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, o.path)
+}
+
+// Append records a mutation at the end of the journal
+func (o *Outbox) Append(entry OutboxEntry) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	// Pick up entries written by other tkan processes
+	if err := o.load(); err != nil {
+		return err
+	}
+
+	entry.Seq = time.Now().UnixNano()
+	if entry.Seq <= o.lastSeq {
+		entry.Seq = o.lastSeq + 1
+	}
+	o.lastSeq = entry.Seq
+	if entry.QueuedAt.IsZero() {
+		entry.QueuedAt = time.Now()
+	}
+
+	o.entries = append(o.entries, entry)
+	if err := o.write(); err != nil {
+		o.entries = o.entries[:len(o.entries)-1]
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	return nil
+}
+
+// Pending returns the entries queued for board, oldest first
+func (o *Outbox) Pending(board string) []OutboxEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var pending []OutboxEntry
+	for _, entry := range o.entries {
+		if entry.Board == board {
+			pending = append(pending, entry)
+		}
+	}
+	return pending
+}
+
+// PendingCards returns the IDs of cards on board with unsent changes
+func (o *Outbox) PendingCards(board string) map[string]bool {
+	ids := map[string]bool{}
+	for _, entry := range o.Pending(board) {
+		ids[entry.CardID] = true
+	}
+	return ids
+}
+
+// OutboxReplayResult summarizes a replay
+type OutboxReplayResult struct {
+	Sent    int              // Entries applied successfully
+	Failed  []error          // Entries the remote rejected; they are dropped from the outbox
+	Created map[string]*Card // Cards created offline, keyed by their local ID
+	Offline bool             // Replay stopped because the remote is still unreachable
+}
+
+// Replay sends board's entries in order using apply, removing each one that completes
+// apply returns the created card for create entries
+// Replay stops at the first offline error and keeps the remaining entries
+func (o *Outbox) Replay(board string, apply func(OutboxEntry) (*Card, error)) OutboxReplayResult {
+	o.replayMu.Lock()
+	defer o.replayMu.Unlock()
+
+	result := OutboxReplayResult{Created: map[string]*Card{}}
+
+	o.mu.Lock()
+	if err := o.load(); err != nil {
+		o.mu.Unlock()
+		result.Failed = append(result.Failed, err)
+		return result
+	}
+	o.mu.Unlock()
+
+	done := map[int64]bool{}
+	for _, entry := range o.Pending(board) {
+		// Cards created earlier in the replay now have remote IDs
+		if created, ok := result.Created[entry.CardID]; ok {
+			entry = remapOutboxEntry(entry, created)
+		}
+
+		// A create that may have reached the remote fails instead of staying queued,
+		// so the next replay can't create it twice
+		card, err := apply(entry)
+		if err != nil && isOfflineError(err) && (entry.Op != OutboxCreate || isUnsentError(err)) {
+			result.Offline = true
+			break
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, fmt.Errorf("%s %s: %w", entry.Op, entry.CardID, err))
+		} else {
+			result.Sent++
+			if entry.Op == OutboxCreate && card != nil {
+				result.Created[entry.CardID] = card
+			}
+		}
+		done[entry.Seq] = true
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	// Entries may have been appended while replaying, so reload before removing ours
+	if err := o.load(); err != nil {
+		result.Failed = append(result.Failed, err)
+		return result
+	}
+	remaining := o.entries[:0]
+	for _, entry := range o.entries {
+		if done[entry.Seq] {
+			continue
+		}
+		if created, ok := result.Created[entry.CardID]; ok && entry.Board == board {
+			entry = remapOutboxEntry(entry, created)
+		}
+		remaining = append(remaining, entry)
+	}
+	o.entries = remaining
+	if err := o.write(); err != nil {
+		result.Failed = append(result.Failed, fmt.Errorf("failed to write outbox: %w", err))
+	}
+	return result
+}
+
+// remapOutboxEntry points an entry for a card created offline at the created remote card
+func remapOutboxEntry(entry OutboxEntry, created *Card) OutboxEntry {
+	entry.CardID = created.ID
+	if entry.Card != nil {
+		card := *entry.Card
+		card.ID = created.ID
+		card.ContentType = created.ContentType
+		card.ContentID = created.ContentID
+		card.URL = created.URL
+		entry.Card = &card
+	}
+	return entry
+}
+
+// ApplyOutbox overlays pending entries onto a freshly loaded board, so unsent
+// changes stay visible until they are replayed
+func ApplyOutbox(board *Board, entries []OutboxEntry) {
+	for _, entry := range entries {
+		switch entry.Op {
+		case OutboxCreate:
+			if entry.Card != nil && board.FindCard(entry.CardID) == nil {
+				card := *entry.Card
+				board.Cards = append(board.Cards, &card)
+			}
+
+		case OutboxMove:
+			if card := board.FindCard(entry.CardID); card != nil {
+				card.Column = entry.Column
+			}
+
+		case OutboxUpdate:
+			card := board.FindCard(entry.CardID)
+			if card == nil || entry.Card == nil {
+				continue
+			}
+			column := card.Column
+			*card = *entry.Card
+			card.Column = column
+		}
+	}
+	board.PopulateColumnCards()
+}
+
+// isOfflineError reports whether err means the remote could not be reached,
+// as opposed to the remote rejecting the request
+func isOfflineError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		return true
+	}
+
+	var apiErr *GitHubAPIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	// gh reports connection failures as text
+	msg := err.Error()
+	for _, s := range []string{"error connecting to", "dial tcp", "no such host", "connection refused", "network is unreachable", "i/o timeout"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnsentError reports whether err means a request never left the machine
+// Only then is it safe to queue a create, which would be duplicated on replay if
+// the remote had already applied it before the connection failed
+func isUnsentError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ENETUNREACH) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	// gh reports connection failures as text
+	msg := err.Error()
+	for _, s := range []string{"error connecting to", "dial tcp", "no such host", "connection refused", "network is unreachable"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
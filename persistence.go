@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -60,6 +61,65 @@ func (b *Board) PopulateColumnCards() {
 	}
 }
 
+// InBoardOrder reports whether a column lists its cards in Board.Cards order, the
+// order PopulateColumnCards (and so reloading the board) gives them
+func (b *Board) InBoardOrder(col *Column) bool {
+	index := make(map[*Card]int, len(b.Cards))
+	for i, card := range b.Cards {
+		index[card] = i
+	}
+	for i := 1; i < len(col.Cards); i++ {
+		if index[col.Cards[i-1]] > index[col.Cards[i]] {
+			return false
+		}
+	}
+	return true
+}
+
+// FindCard returns the card with the given ID, or nil if there is none
+func (b *Board) FindCard(id string) *Card {
+	for _, card := range b.Cards {
+		if card.ID == id {
+			return card
+		}
+	}
+	return nil
+}
+
+// ResolveColumn returns the board's column name matching name case-insensitively
+func (b *Board) ResolveColumn(name string) (string, bool) {
+	for _, col := range b.Columns {
+		if strings.EqualFold(col.Name, name) {
+			return col.Name, true
+		}
+	}
+	return "", false
+}
+
+// NextCardID returns the next free numeric card ID (one past the highest numeric ID)
+func (b *Board) NextCardID() string {
+	maxID := 0
+	for _, card := range b.Cards {
+		var id int
+		fmt.Sscanf(card.ID, "%d", &id)
+		if id > maxID {
+			maxID = id
+		}
+	}
+	return fmt.Sprintf("%d", maxID+1)
+}
+
+// AddCard appends a card to the board and to its column
+func (b *Board) AddCard(card *Card) {
+	b.Cards = append(b.Cards, card)
+	for i := range b.Columns {
+		if b.Columns[i].Name == card.Column {
+			b.Columns[i].Cards = append(b.Columns[i].Cards, card)
+			break
+		}
+	}
+}
+
 // CreateDefaultBoard creates a default board with sample data
 func CreateDefaultBoard() *Board {
 	now := time.Now()
@@ -6,15 +6,13 @@ import (
 	"strings"
 )
 
-// ScanProjects scans for .tkan.yaml files starting from the given directory
+// ScanProjects scans for .tkan.yaml, .tkan.db and .tkan/ boards and TODO.md task lists starting from the given directory
 // It searches the current directory and all subdirectories (up to 3 levels deep)
 func ScanProjects(startDir string) ([]Project, error) {
 	var projects []Project
 
-	// Check if startDir itself has a .tkan.yaml
-	if project, found := checkProjectInDir(startDir); found {
-		projects = append(projects, project)
-	}
+	// Check if startDir itself has a board
+	projects = append(projects, projectsInDir(startDir)...)
 
 	// Walk subdirectories (max 3 levels deep)
 	err := filepath.Walk(startDir, func(path string, info os.FileInfo, err error) error {
@@ -34,10 +32,10 @@ func ScanProjects(startDir string) ([]Project, error) {
 			return filepath.SkipDir
 		}
 
-		// Check if this directory has a .tkan.yaml
+		// Check if this directory has a board
 		if info.IsDir() && path != startDir {
-			if project, found := checkProjectInDir(path); found {
-				projects = append(projects, project)
+			if found := projectsInDir(path); len(found) > 0 {
+				projects = append(projects, found...)
 				return filepath.SkipDir // Don't scan subdirectories of projects
 			}
 		}
@@ -52,6 +50,25 @@ func ScanProjects(startDir string) ([]Project, error) {
 	return projects, nil
 }
 
+// projectsInDir returns the boards in a directory: its .tkan.yaml, .tkan.db and
+// .tkan/ boards and its TODO.md task list
+func projectsInDir(dir string) []Project {
+	var projects []Project
+	if project, found := checkProjectInDir(dir); found {
+		projects = append(projects, project)
+	}
+	if project, found := checkSQLiteProjectInDir(dir); found {
+		projects = append(projects, project)
+	}
+	if project, found := checkDirectoryProjectInDir(dir); found {
+		projects = append(projects, project)
+	}
+	if project, found := checkMarkdownProjectInDir(dir); found {
+		projects = append(projects, project)
+	}
+	return projects
+}
+
 // checkProjectInDir checks if a directory contains a .tkan.yaml file
 func checkProjectInDir(dir string) (Project, bool) {
 	yamlPath := filepath.Join(dir, ".tkan.yaml")
@@ -72,6 +89,73 @@ func checkProjectInDir(dir string) (Project, bool) {
 	return Project{}, false
 }
 
+// checkSQLiteProjectInDir checks if a directory contains a .tkan.db board database
+func checkSQLiteProjectInDir(dir string) (Project, bool) {
+	dbPath := filepath.Join(dir, ".tkan.db")
+	if _, err := os.Stat(dbPath); err != nil {
+		return Project{}, false
+	}
+
+	backend := NewSQLiteBackend(dbPath)
+	defer backend.Close()
+	name := filepath.Base(dir)
+	if boardName, err := backend.BoardName(); err == nil && boardName != "" {
+		name = boardName
+	}
+	return Project{
+		Name: name,
+		Path: dbPath,
+		Dir:  dir,
+	}, true
+}
+
+// checkDirectoryProjectInDir checks if a directory contains a .tkan/ board directory
+func checkDirectoryProjectInDir(dir string) (Project, bool) {
+	boardDir := filepath.Join(dir, ".tkan")
+	if _, err := os.Stat(filepath.Join(boardDir, "board.yaml")); err != nil {
+		return Project{}, false
+	}
+
+	name := filepath.Base(dir)
+	if board, err := NewDirectoryBackend(boardDir).LoadBoard(); err == nil && board.Name != "" {
+		name = board.Name
+	}
+	return Project{
+		Name: name,
+		Path: boardDir,
+		Dir:  dir,
+	}, true
+}
+
+// checkMarkdownProjectInDir checks if a directory has a TODO.md (any case) with
+// "## " column headings and checklist items
+func checkMarkdownProjectInDir(dir string) (Project, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Project{}, false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(entry.Name(), "TODO.md") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if !isMarkdownTaskList(path) {
+			continue
+		}
+
+		name := filepath.Base(dir)
+		if board, err := NewMarkdownBackend(path).LoadBoard(); err == nil && board.Name != "" {
+			name = board.Name
+		}
+		return Project{
+			Name: name,
+			Path: path,
+			Dir:  dir,
+		}, true
+	}
+	return Project{}, false
+}
+
 // GetProjectRelativePath returns a relative path for display
 func GetProjectRelativePath(project Project, baseDir string) string {
 	relPath, err := filepath.Rel(baseDir, project.Dir)
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed 5-field cron expression (minute hour day-of-month month day-of-week)
+type cronSchedule struct {
+	minute [60]bool
+	hour   [24]bool
+	dom    [32]bool // 1-31
+	month  [13]bool // 1-12
+	dow    [7]bool  // 0-6, Sunday = 0
+
+	domRestricted bool // Day-of-month field was not "*"
+	dowRestricted bool // Day-of-week field was not "*"
+}
+
+// weekdayNames maps weekday abbreviations to cron day-of-week numbers
+var weekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// ParseSchedule parses a recurrence schedule into a cron schedule
+// Accepted forms:
+//
+//	daily               every day at midnight
+//	weekly              every Monday
+//	weekly:fri          every Friday (weekly:mon,thu for several days)
+//	monthly             the 1st of every month
+//	monthly:15          the 15th of every month
+//	0 9 * * 1           any 5-field cron expression
+func ParseSchedule(spec string) (*cronSchedule, error) {
+	spec = strings.TrimSpace(strings.ToLower(spec))
+	name, arg, _ := strings.Cut(spec, ":")
+
+	switch name {
+	case "daily":
+		return parseCron("0 0 * * *")
+	case "weekly":
+		if arg == "" {
+			arg = "mon"
+		}
+		var days []string
+		for _, day := range strings.Split(arg, ",") {
+			n, ok := weekdayNames[strings.TrimSpace(day)]
+			if !ok {
+				return nil, fmt.Errorf("invalid weekday %q in schedule %q", day, spec)
+			}
+			days = append(days, strconv.Itoa(n))
+		}
+		return parseCron("0 0 * * " + strings.Join(days, ","))
+	case "monthly":
+		if arg == "" {
+			arg = "1"
+		}
+		return parseCron("0 0 " + arg + " * *")
+	}
+
+	return parseCron(spec)
+}
+
+// parseCron parses a 5-field cron expression
+// Fields support "*", numbers, lists (1,3), ranges (1-5) and steps (*/15, 1-10/2)
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected daily, weekly, monthly or 5 cron fields", expr)
+	}
+
+	s := &cronSchedule{}
+	if err := parseCronField(fields[0], 0, 59, s.minute[:]); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if err := parseCronField(fields[1], 0, 23, s.hour[:]); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if err := parseCronField(fields[2], 1, 31, s.dom[:]); err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	if err := parseCronField(fields[3], 1, 12, s.month[:]); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+
+	// Allow 7 as an alias for Sunday
+	dow := make([]bool, 8)
+	if err := parseCronField(fields[4], 0, 7, dow); err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+	copy(s.dow[:], dow[:7])
+	s.dow[0] = s.dow[0] || dow[7]
+
+	s.domRestricted = fields[2] != "*"
+	s.dowRestricted = fields[4] != "*"
+	return s, nil
+}
+
+// parseCronField marks the values matched by a single cron field in set
+func parseCronField(field string, min, max int, set []bool) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			loStr, hiStr, isRange := strings.Cut(rangePart, "-")
+			n, err := strconv.Atoi(loStr)
+			if err != nil {
+				return fmt.Errorf("invalid value %q", loStr)
+			}
+			lo, hi = n, n
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return fmt.Errorf("invalid value %q", hiStr)
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return fmt.Errorf("value out of range %d-%d in %q", min, max, part)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+// matchesDay reports whether the schedule fires on the given day
+// As in cron, when both day fields are restricted either one may match
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	if !s.month[int(t.Month())] {
+		return false
+	}
+
+	domMatch := s.dom[t.Day()]
+	dowMatch := s.dow[int(t.Weekday())]
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+// Prev returns the latest time at or before t when the schedule fires
+// Returns the zero time if the schedule has not fired in the last five years
+func (s *cronSchedule) Prev(t time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	for i := 0; i < 5*366; i++ {
+		d := day.AddDate(0, 0, -i)
+		if !s.matchesDay(d) {
+			continue
+		}
+
+		startHour := 23
+		if i == 0 {
+			startHour = t.Hour()
+		}
+		for h := startHour; h >= 0; h-- {
+			if !s.hour[h] {
+				continue
+			}
+			startMinute := 59
+			if i == 0 && h == t.Hour() {
+				startMinute = t.Minute()
+			}
+			for min := startMinute; min >= 0; min-- {
+				if s.minute[min] {
+					return time.Date(d.Year(), d.Month(), d.Day(), h, min, 0, 0, d.Location())
+				}
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// ApplyRecurrence creates cards for recurrence rules that are due as of now
+// Each rule generates at most one card per run, even if several occurrences
+// were missed while tkan was not running. A rule without a last run (one just
+// added to the board) fires for its latest occurrence on the first run, so a
+// new weekly rule creates this week's card right away. Invalid rules are
+// reported in the returned error but don't stop the remaining rules.
+// Returns the cards created.
+func ApplyRecurrence(board *Board, templates []CardTemplate, now time.Time) ([]*Card, error) {
+	var created []*Card
+	var errs []error
+
+	for i := range board.Recurring {
+		rule := &board.Recurring[i]
+
+		schedule, err := ParseSchedule(rule.Schedule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recurrence %q: %w", rule.Name, err))
+			continue
+		}
+
+		// Skip if the schedule hasn't fired since the last run
+		occurrence := schedule.Prev(now)
+		if occurrence.IsZero() || !occurrence.After(rule.LastRun) {
+			continue
+		}
+
+		tmpl, err := rule.resolveTemplate(templates)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		card := tmpl.Instantiate(now)
+		card.ID = board.NextCardID()
+		if rule.Column != "" {
+			card.Column = rule.Column
+		}
+		if card.Column == "" && len(board.Columns) > 0 {
+			card.Column = board.Columns[0].Name
+		}
+
+		board.AddCard(card)
+		rule.LastRun = now
+		created = append(created, card)
+	}
+
+	return created, errors.Join(errs...)
+}
+
+// resolveTemplate returns the template a rule instantiates (named or inline)
+func (r *RecurrenceRule) resolveTemplate(templates []CardTemplate) (CardTemplate, error) {
+	if r.Template != "" {
+		tmpl := findTemplate(templates, r.Template)
+		if tmpl == nil {
+			return CardTemplate{}, fmt.Errorf("recurrence %q: template %q not found", r.Name, r.Template)
+		}
+		return *tmpl, nil
+	}
+
+	if r.Card == nil || r.Card.Title == "" {
+		return CardTemplate{}, fmt.Errorf("recurrence %q: needs a template or an inline card with a title", r.Name)
+	}
+	return *r.Card, nil
+}
+
+// tickBoard applies recurrence rules to a local board, saving it if cards were created
+func tickBoard(backend Backend, board *Board, now time.Time) ([]*Card, error) {
+	if len(board.Recurring) == 0 {
+		return nil, nil
+	}
+
+	created, err := ApplyRecurrence(board, AvailableTemplates(board), now)
+	if len(created) > 0 {
+		if saveErr := backend.SaveBoard(board); saveErr != nil {
+			return created, saveErr
+		}
+	}
+	return created, err
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	valid := []string{"daily", "weekly", "Weekly:FRI", "weekly:mon,thu", "monthly", "monthly:15", "0 9 * * 1-5", "*/15 8-18 * * *", "0 0 1 1 7"}
+	for _, spec := range valid {
+		if _, err := ParseSchedule(spec); err != nil {
+			t.Errorf("ParseSchedule(%q) = %v", spec, err)
+		}
+	}
+
+	invalid := []string{"", "hourly", "weekly:someday", "monthly:32", "0 9 * *", "60 * * * *", "0 24 * * *", "0 0 0 * *", "0 0 * 13 *", "*/0 * * * *", "5-1 * * * *"}
+	for _, spec := range invalid {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q) should fail", spec)
+		}
+	}
+}
+
+func TestSchedulePrev(t *testing.T) {
+	// Wednesday 2026-03-18 10:30
+	now := time.Date(2026, 3, 18, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"daily", time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)},
+		{"weekly", time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"weekly:wed", time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)},
+		{"weekly:thu,fri", time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)},
+		{"monthly", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"monthly:31", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},    // February has no 31st
+		{"30 10 * * *", time.Date(2026, 3, 18, 10, 30, 0, 0, time.UTC)}, // Fires exactly now
+		{"31 10 * * *", time.Date(2026, 3, 17, 10, 31, 0, 0, time.UTC)},
+		{"*/20 9-17 * * 1-5", time.Date(2026, 3, 18, 10, 20, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either one may match
+		{"0 0 1 * 2", time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseSchedule(%q): %v", tt.spec, err)
+		}
+		if got := schedule.Prev(now); !got.Equal(tt.want) {
+			t.Errorf("%q: Prev = %s, want %s", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestApplyRecurrenceFiresOncePerOccurrence(t *testing.T) {
+	board := &Board{
+		Columns:   []Column{{Name: "TODO"}, {Name: "DONE"}},
+		Recurring: []RecurrenceRule{{Name: "standup", Schedule: "daily", Card: &CardTemplate{Title: "Standup"}}},
+	}
+	now := time.Date(2026, 3, 18, 10, 30, 0, 0, time.UTC)
+
+	// A new rule fires for its latest occurrence straight away
+	created, err := ApplyRecurrence(board, nil, now)
+	if err != nil || len(created) != 1 || created[0].Column != "TODO" {
+		t.Fatalf("created %+v, %v", created, err)
+	}
+	if created, _ := ApplyRecurrence(board, nil, now.Add(time.Hour)); len(created) != 0 {
+		t.Errorf("same day created %d more cards", len(created))
+	}
+	if created, _ := ApplyRecurrence(board, nil, now.AddDate(0, 0, 3)); len(created) != 1 {
+		t.Errorf("three days later created %d cards, want 1", len(created))
+	}
+}
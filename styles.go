@@ -109,6 +109,31 @@ var (
 			Foreground(colorSubdued).
 			Padding(0, 1)
 
+	// Overdue card style (red border for cards past their due date)
+	styleCardOverdue = lipgloss.NewStyle().
+				Width(cardWidth).
+				Height(cardHeight).
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(colorDanger).
+				Padding(0, 1)
+
+	// Due soon card style (yellow border for cards due within the soon window)
+	styleCardDueSoon = lipgloss.NewStyle().
+				Width(cardWidth).
+				Height(cardHeight).
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(colorWarning).
+				Padding(0, 1)
+
+	// Remotely changed card style (cyan border, shown briefly after a refresh)
+	styleCardChanged = lipgloss.NewStyle().
+				Width(cardWidth).
+				Height(cardHeight).
+				Border(lipgloss.DoubleBorder()).
+				BorderForeground(colorInfo).
+				Foreground(colorInfo).
+				Padding(0, 1)
+
 	// Card content style (for text inside cards)
 	styleCardContent = lipgloss.NewStyle().
 				Width(cardWidth - 2). // Account for padding
@@ -134,6 +159,15 @@ var (
 	styleDetailValue = lipgloss.NewStyle().
 				Foreground(colorForeground)
 
+	// Due date text styles (detail panel, table info box)
+	styleDueOverdue = lipgloss.NewStyle().
+			Foreground(colorDanger).
+			Bold(true)
+
+	styleDueSoon = lipgloss.NewStyle().
+			Foreground(colorWarning).
+			Bold(true)
+
 	styleTag = lipgloss.NewStyle().
 			Foreground(colorInfo).
 			Background(lipgloss.Color("238")).
@@ -150,22 +184,30 @@ var (
 //   │wrapped   │
 //   │here      │
 //   └──────────┘
-func renderCard(title string, selected bool) string {
-	return renderCardWithStyle(title, selected, false)
+func renderCard(title string, selected bool, due DueStatus, changed bool) string {
+	return renderCardWithStyle(title, selected, false, due, changed)
 }
 
 // renderCardGhost renders a faded ghost card (for dragging)
 func renderCardGhost(title string) string {
-	return renderCardWithStyle(title, false, true)
+	return renderCardWithStyle(title, false, true, DueNone, false)
 }
 
 // renderCardWithStyle renders a card with the given title and style options
-func renderCardWithStyle(title string, selected bool, ghost bool) string {
+// Selection and ghost styles take precedence over the remote change highlight,
+// which takes precedence over due date highlighting
+func renderCardWithStyle(title string, selected bool, ghost bool, due DueStatus, changed bool) string {
 	style := styleCard
 	if ghost {
 		style = styleCardGhost
 	} else if selected {
 		style = styleCardSelected
+	} else if changed {
+		style = styleCardChanged
+	} else if due == DueOverdue {
+		style = styleCardOverdue
+	} else if due == DueSoon {
+		style = styleCardDueSoon
 	}
 
 	// Wrap title to fit card width (10 chars with padding)
@@ -177,9 +219,9 @@ func renderCardWithStyle(title string, selected bool, ghost bool) string {
 
 // renderCardTopLines renders just the top 2 lines of a card (for stacking)
 // This creates the Solitaire-style cascading effect
-func renderCardTopLines(title string, selected bool) string {
+func renderCardTopLines(title string, selected bool, due DueStatus, changed bool) string {
 	// Render full card first
-	fullCard := renderCardWithStyle(title, selected, false)
+	fullCard := renderCardWithStyle(title, selected, false, due, changed)
 
 	// Extract just the top 2 lines
 	lines := strings.Split(fullCard, "\n")
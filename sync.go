@@ -0,0 +1,501 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SyncActionKind is what a sync does for one pair of cards
+type SyncActionKind int
+
+const (
+	SyncPush         SyncActionKind = iota // Send local changes to the remote item
+	SyncPull                               // Copy remote changes into the local card
+	SyncCreateRemote                       // Create a remote item for a new local card
+	SyncCreateLocal                        // Create a local card for a new remote item
+	SyncDeleteRemote                       // Delete the remote item of a card deleted locally
+	SyncDeleteLocal                        // Delete the local card of an item deleted remotely
+)
+
+func (k SyncActionKind) String() string {
+	switch k {
+	case SyncPush:
+		return "push"
+	case SyncPull:
+		return "pull"
+	case SyncCreateRemote:
+		return "create remote"
+	case SyncCreateLocal:
+		return "create local"
+	case SyncDeleteRemote:
+		return "delete remote"
+	case SyncDeleteLocal:
+		return "delete local"
+	}
+	return "unknown"
+}
+
+// SyncAction is one change a sync makes
+// Local is nil for SyncCreateLocal and SyncDeleteRemote; Remote is nil for
+// SyncCreateRemote and SyncDeleteLocal
+type SyncAction struct {
+	Kind   SyncActionKind
+	Local  *Card
+	Remote *Card
+}
+
+// ConflictResolution is the side chosen for a conflict
+type ConflictResolution int
+
+const (
+	ResolveUnset      ConflictResolution = iota // Not decided yet
+	ResolveKeepLocal                            // Overwrite the remote with the local card
+	ResolveKeepRemote                           // Overwrite the local card with the remote
+)
+
+// SyncConflict is a card changed on both sides since the last sync
+// Local or Remote is nil when that side deleted the card while the other changed it
+type SyncConflict struct {
+	Local      *Card
+	Remote     *Card
+	Resolution ConflictResolution
+}
+
+// Title returns the conflicting card's title from whichever side still has it
+func (c *SyncConflict) Title() string {
+	if c.Local != nil {
+		return c.Local.Title
+	}
+	return c.Remote.Title
+}
+
+// action returns the sync action for the chosen resolution
+func (c *SyncConflict) action() (SyncAction, bool) {
+	switch c.Resolution {
+	case ResolveKeepLocal:
+		if c.Local == nil {
+			return SyncAction{Kind: SyncDeleteRemote, Remote: c.Remote}, true
+		}
+		if c.Remote == nil {
+			return SyncAction{Kind: SyncCreateRemote, Local: c.Local}, true
+		}
+		return SyncAction{Kind: SyncPush, Local: c.Local, Remote: c.Remote}, true
+	case ResolveKeepRemote:
+		if c.Remote == nil {
+			return SyncAction{Kind: SyncDeleteLocal, Local: c.Local}, true
+		}
+		if c.Local == nil {
+			return SyncAction{Kind: SyncCreateLocal, Remote: c.Remote}, true
+		}
+		return SyncAction{Kind: SyncPull, Local: c.Local, Remote: c.Remote}, true
+	}
+	return SyncAction{}, false
+}
+
+// SyncPlan is the set of changes that brings a local board and a remote project together
+type SyncPlan struct {
+	Actions   []SyncAction
+	Conflicts []*SyncConflict
+}
+
+// Unresolved returns the number of conflicts without a resolution
+func (p *SyncPlan) Unresolved() int {
+	n := 0
+	for _, c := range p.Conflicts {
+		if c.Resolution == ResolveUnset {
+			n++
+		}
+	}
+	return n
+}
+
+// ResolveAll sets the resolution of every undecided conflict
+func (p *SyncPlan) ResolveAll(resolution ConflictResolution) {
+	for _, c := range p.Conflicts {
+		if c.Resolution == ResolveUnset {
+			c.Resolution = resolution
+		}
+	}
+}
+
+// PlanSync compares a local board with the remote project's board
+// Cards are paired by RemoteID (and by title on the first sync). A card changed
+// on one side since the last sync (by ModifiedAt) is copied to the other; a card
+// changed on both sides with different content is a conflict
+func PlanSync(local, remote *Board) *SyncPlan {
+	plan := &SyncPlan{}
+	var lastSynced time.Time
+	var known []string
+	if local.Sync != nil {
+		lastSynced = local.Sync.LastSynced
+		known = local.Sync.Known
+	}
+
+	remoteByID := map[string]*Card{}
+	for _, card := range remote.Cards {
+		remoteByID[card.ID] = card
+	}
+	paired := map[string]bool{}
+
+	// First sync: pair cards with the same title, so an imported board isn't duplicated
+	if lastSynced.IsZero() {
+		for _, card := range local.Cards {
+			if card.RemoteID != "" {
+				continue
+			}
+			for _, r := range remote.Cards {
+				if !paired[r.ID] && r.Title == card.Title && !remoteIDInUse(local, r.ID) {
+					card.RemoteID = r.ID
+					paired[r.ID] = true
+					break
+				}
+			}
+		}
+	}
+
+	for _, card := range local.Cards {
+		if card.RemoteID == "" {
+			plan.Actions = append(plan.Actions, SyncAction{Kind: SyncCreateRemote, Local: card})
+			continue
+		}
+
+		r, ok := remoteByID[card.RemoteID]
+		localChanged := card.ModifiedAt.After(lastSynced)
+		if !ok {
+			// Deleted remotely
+			if localChanged && !lastSynced.IsZero() {
+				plan.Conflicts = append(plan.Conflicts, &SyncConflict{Local: card})
+			} else {
+				plan.Actions = append(plan.Actions, SyncAction{Kind: SyncDeleteLocal, Local: card})
+			}
+			continue
+		}
+		paired[r.ID] = true
+
+		if syncFieldsEqual(card, r) {
+			continue
+		}
+		remoteChanged := r.ModifiedAt.After(lastSynced)
+		switch {
+		case localChanged && remoteChanged:
+			plan.Conflicts = append(plan.Conflicts, &SyncConflict{Local: card, Remote: r})
+		case localChanged:
+			plan.Actions = append(plan.Actions, SyncAction{Kind: SyncPush, Local: card, Remote: r})
+		default:
+			plan.Actions = append(plan.Actions, SyncAction{Kind: SyncPull, Local: card, Remote: r})
+		}
+	}
+
+	for _, r := range remote.Cards {
+		if paired[r.ID] {
+			continue
+		}
+		if !slices.Contains(known, r.ID) {
+			plan.Actions = append(plan.Actions, SyncAction{Kind: SyncCreateLocal, Remote: r})
+			continue
+		}
+		// Deleted locally
+		if r.ModifiedAt.After(lastSynced) {
+			plan.Conflicts = append(plan.Conflicts, &SyncConflict{Remote: r})
+		} else {
+			plan.Actions = append(plan.Actions, SyncAction{Kind: SyncDeleteRemote, Remote: r})
+		}
+	}
+
+	return plan
+}
+
+// remoteIDInUse reports whether any local card is already paired with the remote item
+func remoteIDInUse(board *Board, remoteID string) bool {
+	for _, card := range board.Cards {
+		if card.RemoteID == remoteID {
+			return true
+		}
+	}
+	return false
+}
+
+// syncFieldsEqual compares the fields that sync copies between a local card and a remote item
+// Draft issues have no labels, so tags only count for issues and pull requests
+func syncFieldsEqual(local, remote *Card) bool {
+	if local.Title != remote.Title ||
+		local.Description != remote.Description ||
+		local.Column != remote.Column ||
+		normalizeAssignees(local.Assignee) != normalizeAssignees(remote.Assignee) {
+		return false
+	}
+	if remote.ContentType == "DraftIssue" {
+		return true
+	}
+	return strings.Join(sortedLower(local.Tags), ",") == strings.Join(sortedLower(remote.Tags), ",")
+}
+
+// normalizeAssignees puts an assignee list in a comparable form ("@b, a" -> "a,b")
+func normalizeAssignees(assignee string) string {
+	return strings.Join(sortedLower(parseAssignees(assignee)), ",")
+}
+
+// sortedLower returns the strings lowercased and sorted
+func sortedLower(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.ToLower(v)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// SyncResult summarizes an applied sync
+type SyncResult struct {
+	Applied map[SyncActionKind]int
+	Errors  []error // Actions that failed; the next sync tries them again
+}
+
+// String summarizes the result in one line
+func (r SyncResult) String() string {
+	var parts []string
+	for kind := SyncPush; kind <= SyncDeleteLocal; kind++ {
+		if n := r.Applied[kind]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, kind))
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "already in sync")
+	}
+	if len(r.Errors) > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", len(r.Errors)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ApplySync carries out a plan (including resolved conflicts) against the remote
+// backend and updates the local board; the caller saves the board
+// Every conflict must be resolved first: LastSynced moves forward, so a skipped
+// conflict would look unchanged to the next sync
+func ApplySync(local *Board, remote Backend, plan *SyncPlan) (SyncResult, error) {
+	if n := plan.Unresolved(); n > 0 {
+		return SyncResult{}, fmt.Errorf("%d unresolved conflict(s)", n)
+	}
+
+	result := SyncResult{Applied: map[SyncActionKind]int{}}
+	actions := append([]SyncAction{}, plan.Actions...)
+	for _, c := range plan.Conflicts {
+		action, _ := c.action()
+		actions = append(actions, action)
+	}
+
+	var retryPush []*Card
+	var retryDelete []string
+	for _, action := range actions {
+		if err := applySyncAction(local, remote, action); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("%s %q: %w", action.Kind, syncActionTitle(action), err))
+			switch action.Kind {
+			case SyncPush:
+				retryPush = append(retryPush, action.Local)
+			case SyncDeleteRemote:
+				retryDelete = append(retryDelete, action.Remote.ID)
+			}
+			continue
+		}
+		result.Applied[action.Kind]++
+	}
+
+	now := time.Now()
+	if local.Sync == nil {
+		local.Sync = &SyncState{}
+	}
+	local.Sync.LastSynced = now
+	local.Sync.Known = retryDelete // Still known, so the delete is retried
+	for _, card := range local.Cards {
+		if card.RemoteID != "" {
+			local.Sync.Known = append(local.Sync.Known, card.RemoteID)
+		}
+	}
+	// Failed pushes stay newer than LastSynced so they are pushed again
+	// (cards that failed to be created have no RemoteID and are retried anyway)
+	for _, card := range retryPush {
+		card.ModifiedAt = now.Add(time.Nanosecond)
+	}
+	local.PopulateColumnCards()
+	return result, nil
+}
+
+// SyncSession is a planned sync between a local board and its GitHub project
+type SyncSession struct {
+	Path   string  // Local board file or directory
+	Store  Backend // The local board, as NewFileBackend opens Path
+	Local  *Board  // Loaded from Store
+	Remote Backend // The GitHub project
+	Plan   *SyncPlan
+}
+
+// StartSync loads a local board, fetches its GitHub project and plans the sync
+// spec and host link the board to a project (or confirm the existing link); when
+// spec is empty the board must already be linked
+// The session keeps the local board open until Close
+func StartSync(path, spec, host string) (session *SyncSession, err error) {
+	store := NewFileBackend(path)
+	if _, ok := store.(*MarkdownBackend); ok {
+		return nil, fmt.Errorf("%s is a Markdown task list, which can't store sync state; tkan migrate it to another format first", path)
+	}
+	defer func() {
+		if err != nil {
+			closeBackend(store)
+		}
+	}()
+
+	local, err := store.LoadBoard()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case spec == "" && local.Sync == nil:
+		return nil, fmt.Errorf("%s isn't linked to a GitHub project; run: tkan sync --github owner/project-number", path)
+	case spec == "":
+		spec = local.Sync.Remote
+		if host == "" {
+			host = local.Sync.Host
+		}
+	case local.Sync != nil && local.Sync.Remote != spec:
+		return nil, fmt.Errorf("%s is synced with %s; remove its sync: section to link it to %s", path, local.Sync.Remote, spec)
+	}
+
+	owner, repoName, projectNum, err := ParseGitHubProjectSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	host = ResolveGitHubHost(host, spec)
+	remote := NewGitHubBackend(host, owner, projectNum, repoName)
+	remoteBoard, err := remote.LoadBoard()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GitHub project %s: %w", spec, err)
+	}
+
+	if local.Sync == nil {
+		local.Sync = &SyncState{Remote: spec}
+	}
+	if host != defaultGitHubHost {
+		local.Sync.Host = host
+	}
+
+	return &SyncSession{
+		Path:   path,
+		Store:  store,
+		Local:  local,
+		Remote: remote,
+		Plan:   PlanSync(local, remoteBoard),
+	}, nil
+}
+
+// Apply carries out the plan and saves the local board
+func (s *SyncSession) Apply() (SyncResult, error) {
+	result, err := ApplySync(s.Local, s.Remote, s.Plan)
+	if err != nil {
+		return result, err
+	}
+	s.Local.ModifiedAt = time.Now()
+	if err := s.Store.SaveBoard(s.Local); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Close closes the local board
+func (s *SyncSession) Close() {
+	closeBackend(s.Store)
+}
+
+// closeBackend closes a backend that holds resources, such as an open database
+func closeBackend(backend Backend) {
+	if closer, ok := backend.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// syncActionTitle returns the title of the card an action is about
+func syncActionTitle(action SyncAction) string {
+	if action.Local != nil {
+		return action.Local.Title
+	}
+	return action.Remote.Title
+}
+
+// applySyncAction performs one sync action
+func applySyncAction(local *Board, remote Backend, action SyncAction) error {
+	switch action.Kind {
+	case SyncPush:
+		return pushCard(remote, action.Local, action.Remote)
+
+	case SyncPull:
+		pullCard(action.Local, action.Remote)
+		return nil
+
+	case SyncCreateRemote:
+		created, err := remote.CreateCard(action.Local.Title, action.Local.Description, action.Local.Column)
+		if created == nil {
+			return err
+		}
+		action.Local.RemoteID = created.ID
+		action.Local.ContentType = created.ContentType
+		action.Local.ContentID = created.ContentID
+		if err != nil {
+			return err
+		}
+		if action.Local.Assignee == "" && len(action.Local.Tags) == 0 {
+			return nil
+		}
+		return pushCard(remote, action.Local, created)
+
+	case SyncCreateLocal:
+		card := &Card{ID: local.NextCardID(), CreatedAt: action.Remote.CreatedAt}
+		pullCard(card, action.Remote)
+		local.Cards = append(local.Cards, card)
+		return nil
+
+	case SyncDeleteRemote:
+		return remote.DeleteCard(action.Remote.ID)
+
+	case SyncDeleteLocal:
+		local.Cards = slices.DeleteFunc(local.Cards, func(c *Card) bool { return c == action.Local })
+		return nil
+	}
+	return fmt.Errorf("unknown sync action %d", action.Kind)
+}
+
+// pushCard writes a local card's fields to its remote item
+func pushCard(remote Backend, local, item *Card) error {
+	update := *local
+	update.ID = item.ID
+	update.ContentType = item.ContentType
+	update.ContentID = item.ContentID
+	if update.ContentType == "DraftIssue" {
+		update.Tags = nil // Draft issues can't have labels
+	}
+	if err := remote.UpdateCard(&update); err != nil {
+		return err
+	}
+	if local.Column != item.Column {
+		return remote.MoveCard(item.ID, local.Column)
+	}
+	return nil
+}
+
+// pullCard copies a remote item's fields into a local card
+func pullCard(local, item *Card) {
+	local.Title = item.Title
+	local.Description = item.Description
+	local.Column = item.Column
+	local.Assignee = item.Assignee
+	if item.ContentType != "DraftIssue" {
+		local.Tags = item.Tags
+	}
+	local.URL = item.URL
+	local.RemoteID = item.ID
+	local.ContentType = item.ContentType
+	local.ContentID = item.ContentID
+	local.ModifiedAt = item.ModifiedAt
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// planKinds counts the actions of a plan by kind
+func planKinds(plan *SyncPlan) map[SyncActionKind]int {
+	kinds := map[SyncActionKind]int{}
+	for _, action := range plan.Actions {
+		kinds[action.Kind]++
+	}
+	return kinds
+}
+
+func TestPlanSync(t *testing.T) {
+	synced := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	before, after := synced.Add(-time.Hour), synced.Add(time.Hour)
+
+	local := &Board{
+		Sync: &SyncState{Remote: "acme/1", LastSynced: synced, Known: []string{"R1", "R2", "R3", "R4", "R5", "R6", "R7"}},
+		Cards: []*Card{
+			{ID: "1", Title: "Unchanged", Column: "TODO", RemoteID: "R1", ModifiedAt: before},
+			{ID: "2", Title: "Edited locally", Column: "DONE", RemoteID: "R2", ModifiedAt: after},
+			{ID: "3", Title: "Old title", Column: "TODO", RemoteID: "R3", ModifiedAt: before},
+			{ID: "4", Title: "Edited here", Column: "TODO", RemoteID: "R4", ModifiedAt: after},
+			{ID: "5", Title: "Deleted remotely", Column: "TODO", RemoteID: "R5", ModifiedAt: before},
+			{ID: "8", Title: "New local card", Column: "TODO"},
+		},
+	}
+	remote := &Board{Cards: []*Card{
+		{ID: "R1", Title: "Unchanged", Column: "TODO", ModifiedAt: before},
+		{ID: "R2", Title: "Edited locally", Column: "TODO", ModifiedAt: before},
+		{ID: "R3", Title: "New title", Column: "TODO", ModifiedAt: after},
+		{ID: "R4", Title: "Edited there", Column: "TODO", ModifiedAt: after},
+		{ID: "R6", Title: "Deleted locally", Column: "TODO", ModifiedAt: before},
+		{ID: "R7", Title: "Deleted locally, edited remotely", Column: "TODO", ModifiedAt: after},
+		{ID: "R9", Title: "New remote item", Column: "TODO", ModifiedAt: after},
+	}}
+
+	plan := PlanSync(local, remote)
+	want := map[SyncActionKind]int{
+		SyncPush:         1,
+		SyncPull:         1,
+		SyncCreateRemote: 1,
+		SyncCreateLocal:  1,
+		SyncDeleteRemote: 1,
+		SyncDeleteLocal:  1,
+	}
+	got := planKinds(plan)
+	for kind, n := range want {
+		if got[kind] != n {
+			t.Errorf("%s actions = %d, want %d (plan %+v)", kind, got[kind], n, plan.Actions)
+		}
+	}
+	if len(plan.Conflicts) != 2 {
+		t.Fatalf("conflicts = %d, want 2", len(plan.Conflicts))
+	}
+	if plan.Conflicts[0].Title() != "Edited here" || plan.Conflicts[1].Local != nil {
+		t.Errorf("unexpected conflicts %+v, %+v", plan.Conflicts[0], plan.Conflicts[1])
+	}
+}
+
+func TestPlanSyncFirstSyncPairsByTitle(t *testing.T) {
+	local := &Board{Cards: []*Card{
+		{ID: "1", Title: "Shared", Column: "TODO"},
+		{ID: "2", Title: "Only local", Column: "TODO"},
+	}}
+	remote := &Board{Cards: []*Card{
+		{ID: "R1", Title: "Shared", Column: "TODO"},
+		{ID: "R2", Title: "Only remote", Column: "TODO"},
+	}}
+
+	plan := PlanSync(local, remote)
+	if local.Cards[0].RemoteID != "R1" {
+		t.Errorf("RemoteID = %q, want R1", local.Cards[0].RemoteID)
+	}
+	got := planKinds(plan)
+	if len(plan.Actions) != 2 || got[SyncCreateRemote] != 1 || got[SyncCreateLocal] != 1 || len(plan.Conflicts) != 0 {
+		t.Errorf("plan = %+v", plan)
+	}
+}
+
+func TestApplySync(t *testing.T) {
+	remotePath := filepath.Join(t.TempDir(), "remote.yaml")
+	remoteBoard := &Board{Name: "Remote", Columns: []Column{{Name: "TODO"}, {Name: "DOING"}, {Name: "DONE"}}}
+	remoteBoard.Cards = []*Card{
+		{ID: "1", Title: "Both edited", Column: "TODO", ModifiedAt: time.Now()},
+		{ID: "2", Title: "Remote only", Column: "DONE", ModifiedAt: time.Now()},
+	}
+	if err := SaveBoard(remotePath, remoteBoard); err != nil {
+		t.Fatal(err)
+	}
+	remote := NewLocalBackend(remotePath)
+
+	synced := time.Now().Add(-time.Hour)
+	local := &Board{Name: "Local", Columns: remoteBoard.Columns}
+	local.Sync = &SyncState{Remote: "acme/1", LastSynced: synced, Known: []string{"1"}}
+	local.Cards = []*Card{
+		{ID: "1", Title: "Both edited, locally", Column: "DOING", RemoteID: "1", ModifiedAt: time.Now()},
+		{ID: "2", Title: "Local only", Column: "TODO", ModifiedAt: time.Now()},
+	}
+
+	plan := PlanSync(local, remoteBoard)
+	if _, err := ApplySync(local, remote, plan); err == nil {
+		t.Fatal("ApplySync should refuse unresolved conflicts")
+	}
+	plan.ResolveAll(ResolveKeepLocal)
+	result, err := ApplySync(local, remote, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("errors: %v", result.Errors)
+	}
+
+	after, err := remote.LoadBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card := after.FindCard("1"); card == nil || card.Title != "Both edited, locally" || card.Column != "DOING" {
+		t.Errorf("remote card 1 = %+v, want local version", card)
+	}
+	if len(after.Cards) != 3 {
+		t.Errorf("remote has %d cards, want 3", len(after.Cards))
+	}
+	if len(local.Cards) != 3 || local.Cards[1].RemoteID == "" {
+		t.Errorf("local cards = %+v", local.Cards)
+	}
+	if !local.Sync.LastSynced.After(synced) || len(local.Sync.Known) != 3 {
+		t.Errorf("sync state = %+v", local.Sync)
+	}
+
+	// Nothing left to do on the next sync
+	if plan := PlanSync(local, after); len(plan.Actions) != 0 || len(plan.Conflicts) != 0 {
+		t.Errorf("second plan = %+v", plan)
+	}
+}
+
+func TestSyncSessionSavesThroughBoardBackend(t *testing.T) {
+	remotePath := filepath.Join(t.TempDir(), "remote.yaml")
+	remoteBoard := &Board{Name: "Remote", Columns: []Column{{Name: "TODO"}, {Name: "DONE"}}}
+	remoteBoard.Cards = []*Card{{ID: "1", Title: "Remote only", Column: "TODO", ModifiedAt: time.Now()}}
+	if err := SaveBoard(remotePath, remoteBoard); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{".tkan.db", ".tkan"} {
+		path := filepath.Join(t.TempDir(), name)
+		store := NewFileBackend(path)
+		board := &Board{Name: "Local", Columns: remoteBoard.Columns, Sync: &SyncState{Remote: "acme/1"}}
+		if err := store.SaveBoard(board); err != nil {
+			t.Fatal(err)
+		}
+		local, err := store.LoadBoard()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		session := &SyncSession{Path: path, Store: store, Local: local, Remote: NewLocalBackend(remotePath)}
+		session.Plan = PlanSync(local, remoteBoard)
+		if _, err := session.Apply(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		session.Close()
+
+		reopened := NewFileBackend(path)
+		after, err := reopened.LoadBoard()
+		closeBackend(reopened)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if after.Sync == nil || after.Sync.Remote != "acme/1" || len(after.Cards) != 1 || after.Cards[0].RemoteID != "1" {
+			t.Errorf("%s after sync: sync %+v, cards %+v", name, after.Sync, after.Cards)
+		}
+	}
+}
+
+func TestStartSyncRejectsMarkdownBoards(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "TODO.md")
+	if err := os.WriteFile(path, []byte("## TODO\n\n- [ ] Task\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := StartSync(path, "acme/1", ""); err == nil || !strings.Contains(err.Error(), "Markdown") {
+		t.Errorf("StartSync on a Markdown board = %v", err)
+	}
+}
+
+func TestSyncBlocksBoardEdits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tkan.yaml")
+	board := sqliteTestBoard()
+	if err := SaveBoard(path, board); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModelWithBackend(board, nil, NewLocalBackend(path))
+	m.syncing = true
+
+	// The running sync saves the board it loaded, so an edit now would be lost
+	if m.deleteCardByID("1") != nil || m.board.FindCard("1") == nil {
+		t.Error("a card was deleted during a sync")
+	}
+	if m.moveCard(0, 0, 2, 0) != nil || m.board.FindCard("1").Column != "TODO" {
+		t.Error("a card was moved during a sync")
+	}
+	if m.openCreateCardForm(); m.formMode != FormNone {
+		t.Error("the new card form opened during a sync")
+	}
+	if m.statusMessage == "" {
+		t.Error("blocked edits should say why")
+	}
+}
+
+func TestSyncConflictsViewSurvivesHelp(t *testing.T) {
+	board := sqliteTestBoard()
+	board.Sync = &SyncState{Remote: "acme/1"}
+	session := &SyncSession{Local: board, Plan: &SyncPlan{Conflicts: []*SyncConflict{
+		{Local: board.Cards[0], Remote: board.Cards[0]},
+	}}}
+
+	for _, finish := range []string{"esc", "enter"} {
+		var model tea.Model = NewModelWithBackend(board, nil, NewLocalBackend(filepath.Join(t.TempDir(), ".tkan.yaml")))
+		m := model.(Model)
+		m.setSize(120, 40)
+		m.syncing = true
+		model, _ = m.handleSyncPlanned(syncPlannedMsg{session: session})
+
+		// The commands sync would run are dropped; only the view state matters here
+		press := func(key tea.KeyMsg) {
+			model, _ = model.(Model).handleKeyMsg(key)
+		}
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+		press(tea.KeyMsg{Type: tea.KeyEsc})
+		if got := model.(Model).viewMode; got != ViewSyncConflicts {
+			t.Fatalf("%s: help returned to view %v, want the conflicts", finish, got)
+		}
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+		if finish == "esc" {
+			press(tea.KeyMsg{Type: tea.KeyEsc})
+		} else {
+			press(tea.KeyMsg{Type: tea.KeyEnter})
+		}
+		if got := model.(Model).viewMode; got != ViewBoard {
+			t.Errorf("%s: left the conflicts for view %v, want the board", finish, got)
+		}
+		press(tea.KeyMsg{Type: tea.KeyDown})
+		_ = model.(Model).View()
+	}
+}
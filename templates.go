@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templateDateToken is replaced with the creation date when a template is instantiated
+const templateDateToken = "{date}"
+
+// LoadUserTemplates loads card templates from ~/.config/tkan/templates
+// Each *.yaml file holds one template; its name defaults to the file name
+func LoadUserTemplates() ([]CardTemplate, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	return loadTemplatesFromDir(filepath.Join(dir, "templates"))
+}
+
+// loadTemplatesFromDir loads every *.yaml/*.yml template in dir (missing dir = no templates)
+func loadTemplatesFromDir(dir string) ([]CardTemplate, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	var templates []CardTemplate
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", entry.Name(), err)
+		}
+
+		var tmpl CardTemplate
+		if err := yaml.Unmarshal(data, &tmpl); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", entry.Name(), err)
+		}
+		if tmpl.Name == "" {
+			tmpl.Name = strings.TrimSuffix(entry.Name(), ext)
+		}
+		templates = append(templates, tmpl)
+	}
+
+	return templates, nil
+}
+
+// AvailableTemplates returns the board's templates followed by the user's
+// User templates with the same name as a board template are skipped
+func AvailableTemplates(board *Board) []CardTemplate {
+	templates := append([]CardTemplate{}, board.Templates...)
+
+	userTemplates, err := LoadUserTemplates()
+	if err != nil {
+		return templates
+	}
+
+	for _, tmpl := range userTemplates {
+		if findTemplate(templates, tmpl.Name) == nil {
+			templates = append(templates, tmpl)
+		}
+	}
+	return templates
+}
+
+// findTemplate returns the template with the given name (case-insensitive), or nil
+func findTemplate(templates []CardTemplate, name string) *CardTemplate {
+	for i := range templates {
+		if strings.EqualFold(templates[i].Name, name) {
+			return &templates[i]
+		}
+	}
+	return nil
+}
+
+// Instantiate creates a new card from the template (ID and column are left to the caller)
+func (t CardTemplate) Instantiate(now time.Time) *Card {
+	card := &Card{
+		Title:       expandTemplateText(t.Title, now),
+		Description: expandTemplateText(t.Description, now),
+		Tags:        append([]string(nil), t.Tags...),
+		Assignee:    t.Assignee,
+		Column:      t.Column,
+		CreatedAt:   now,
+		ModifiedAt:  now,
+	}
+	if t.DueInDays > 0 {
+		card.DueDate = startOfDay(now).AddDate(0, 0, t.DueInDays).Format(dueDateLayout)
+	}
+	return card
+}
+
+// expandTemplateText replaces template tokens in s
+func expandTemplateText(s string, now time.Time) string {
+	return strings.ReplaceAll(s, templateDateToken, now.Format(dueDateLayout))
+}
@@ -9,16 +9,45 @@ import (
 
 // Card represents a single task card
 type Card struct {
-	ID          string    `yaml:"id"`
-	Title       string    `yaml:"title"`
-	Description string    `yaml:"description"`
-	Tags        []string  `yaml:"tags,omitempty"`
-	Assignee    string    `yaml:"assignee,omitempty"`
-	DueDate     string    `yaml:"due_date,omitempty"`
-	URL         string    `yaml:"url,omitempty"` // Link to GitHub issue/PR or external URL
-	CreatedAt   time.Time `yaml:"created_at"`
-	ModifiedAt  time.Time `yaml:"modified_at"`
-	Column      string    `yaml:"column"` // Which column this card belongs to
+	ID          string    `yaml:"id" json:"id"`
+	Title       string    `yaml:"title" json:"title"`
+	Description string    `yaml:"description" json:"description"`
+	Tags        []string  `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Assignee    string    `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	DueDate     string    `yaml:"due_date,omitempty" json:"due_date,omitempty"`
+	URL         string    `yaml:"url,omitempty" json:"url,omitempty"` // Link to GitHub issue/PR or external URL
+	CreatedAt   time.Time `yaml:"created_at" json:"created_at"`
+	ModifiedAt  time.Time `yaml:"modified_at" json:"modified_at"`
+	Column      string    `yaml:"column" json:"column"` // Which column this card belongs to
+
+	// GitHub content behind the card (empty for local cards)
+	ContentType string `yaml:"content_type,omitempty" json:"content_type,omitempty"` // DraftIssue, Issue or PullRequest
+	ContentID   string `yaml:"content_id,omitempty" json:"content_id,omitempty"`     // Node ID of the draft issue, issue or PR
+	RemoteID    string `yaml:"remote_id,omitempty" json:"remote_id,omitempty"`       // Project item paired with this card by tkan sync
+}
+
+// CardTemplate is a reusable blueprint for new cards
+// Templates live in the board (templates:) or as YAML files in ~/.config/tkan/templates
+// "{date}" in the title or description expands to the creation date (YYYY-MM-DD)
+type CardTemplate struct {
+	Name        string   `yaml:"name"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	Assignee    string   `yaml:"assignee,omitempty"`
+	Column      string   `yaml:"column,omitempty"`      // Default column for recurring cards
+	DueInDays   int      `yaml:"due_in_days,omitempty"` // Due date relative to creation (0 = none)
+}
+
+// RecurrenceRule generates a card from a template on a schedule
+// Schedule is "daily", "weekly[:mon,thu]", "monthly[:15]" or a 5-field cron expression
+type RecurrenceRule struct {
+	Name     string        `yaml:"name"`
+	Schedule string        `yaml:"schedule"`
+	Template string        `yaml:"template,omitempty"` // Name of a board or user template
+	Card     *CardTemplate `yaml:"card,omitempty"`     // Inline template (used when Template is empty)
+	Column   string        `yaml:"column,omitempty"`   // Target column (overrides the template's column)
+	LastRun  time.Time     `yaml:"last_run,omitempty"` // When this rule last generated a card
 }
 
 // Column represents a column in the Kanban board
@@ -29,13 +58,24 @@ type Column struct {
 
 // Board represents the entire Kanban board
 type Board struct {
-	Name        string    `yaml:"name"`
-	Description string    `yaml:"description,omitempty"`
-	URL         string    `yaml:"url,omitempty"` // Link to GitHub project or external URL
-	Columns     []Column  `yaml:"columns"`
-	Cards       []*Card   `yaml:"cards"`
-	CreatedAt   time.Time `yaml:"created_at"`
-	ModifiedAt  time.Time `yaml:"modified_at"`
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description,omitempty"`
+	URL         string           `yaml:"url,omitempty"` // Link to GitHub project or external URL
+	Columns     []Column         `yaml:"columns"`
+	Cards       []*Card          `yaml:"cards"`
+	Templates   []CardTemplate   `yaml:"templates,omitempty"`
+	Recurring   []RecurrenceRule `yaml:"recurring,omitempty"`
+	Sync        *SyncState       `yaml:"sync,omitempty"` // GitHub project mirrored by tkan sync
+	CreatedAt   time.Time        `yaml:"created_at"`
+	ModifiedAt  time.Time        `yaml:"modified_at"`
+}
+
+// SyncState records the GitHub project a local board is synced with
+type SyncState struct {
+	Remote     string    `yaml:"remote"`                // GitHub project spec (owner/N or owner/repo/N)
+	Host       string    `yaml:"host,omitempty"`        // GitHub host, if not the default
+	LastSynced time.Time `yaml:"last_synced,omitempty"` // When the last sync finished
+	Known      []string  `yaml:"known,omitempty"`       // Project item IDs paired at the last sync
 }
 
 // ViewMode represents the current view (project list, board, table, or help)
@@ -47,6 +87,7 @@ const (
 	ViewTable
 	ViewHelp
 	ViewProjectSource
+	ViewSyncConflicts // Resolving conflicts found by a GitHub sync
 )
 
 // FormMode represents the current form state
@@ -56,6 +97,8 @@ const (
 	FormNone FormMode = iota // No form active
 	FormCreateCard           // Creating a new card
 	FormEditCard             // Editing an existing card
+	FormPickTemplate         // Choosing a template before creating a card
+	FormExport               // Choosing an export format (table view)
 )
 
 // Model is the Bubbletea model for the entire application
@@ -65,6 +108,20 @@ type Model struct {
 	projects       []Project // List of available projects
 	selectedProject int      // Which project is selected in project list
 	backend        Backend   // Backend for persistence
+	githubHost     string    // --github-host flag (see ResolveGitHubHost)
+
+	// Remote sync
+	outbox        *Outbox              // Journal for GitHub changes made while offline
+	replaying     bool                 // An outbox replay is in flight
+	lastSynced    time.Time            // When the board was last fetched from a remote backend (zero for local boards)
+	refreshing    bool                 // A background board refresh is in flight
+	refreshEvery  time.Duration        // Polling interval for remote boards (0 disables polling)
+	remoteChanges map[string]time.Time // Card IDs changed by the last refresh, highlighted until the given time
+	deletedCards  map[string]time.Time // Card IDs deleted locally and when, kept out of refreshes that started earlier
+	syncSession   *SyncSession         // Sync waiting for conflict resolution (see ViewSyncConflicts)
+	syncConflict  int                  // Selected conflict in the resolution view
+	syncReturn    ViewMode             // View to return to once the conflicts are resolved or cancelled
+	syncing       bool                 // A tkan sync is being planned or applied
 
 	// UI State
 	viewMode          ViewMode
@@ -74,12 +131,14 @@ type Model struct {
 	selectedSourceOpt int      // Which project source option is selected
 	showDetails       bool     // Show detail panel
 	showArchive       bool     // Show archive column
+	dueSoonDays       int      // Cards due within this many days are highlighted
 	width             int
 	height            int
 
 	// Table view
 	table          *table.Table // Table component for table view
 	tableCardIndex []*Card      // Maps table row index to card (for edit/delete operations)
+	tableSortDue   bool         // Order table rows by parsed due date
 
 	// Layout (calculated from width/height)
 	boardWidth  int // Width of the board area (67% when details shown, 100% when hidden)
@@ -107,11 +166,27 @@ type Model struct {
 	formInputs    []textinput.Model // Text inputs for the form
 	formFocusIndex int            // Which input is currently focused
 	editingCardID string          // ID of card being edited (empty if creating)
+	formError     string          // Validation error shown in the form (empty if none)
+	formSaving    bool            // The form's card is being saved in the background
+	formTemplate  *CardTemplate   // Template the create form was started from (nil for blank)
+
+	// Template picker (shown on "n" when templates exist)
+	templates        []CardTemplate // Templates available to the picker
+	selectedTemplate int            // Picker selection (0 = blank card, i+1 = templates[i])
+
+	// Export picker (table view)
+	selectedExportOpt int // Index into exportFormats
+
+	// Status message shown in the status bar until the next key press
+	statusMessage string
 
 	// Delete confirmation
 	confirmingDelete bool   // Whether we're showing delete confirmation
 	deletingCardID   string // ID of card pending deletion
 
+	// Convert-to-issue confirmation
+	convertingCardID string // ID of draft card pending conversion to an issue
+
 	// Double-click detection
 	lastClickTime time.Time
 	lastClickX    int
@@ -121,7 +196,7 @@ type Model struct {
 // Project represents a discovered project with a .tkan.yaml file
 type Project struct {
 	Name string // Display name (from board or directory name)
-	Path string // Full path to .tkan.yaml file
+	Path string // Full path to the board (.tkan.yaml, .tkan.db, .tkan/ or TODO.md)
 	Dir  string // Directory containing the project
 }
 
@@ -134,6 +209,64 @@ type Layout struct {
 
 // Msg types for Bubbletea
 type boardLoadedMsg struct {
-	board *Board
-	err   error
+	backend Backend // Where the board came from; results for a project we've left are dropped
+	board   *Board
+	err     error
+	started time.Time // When the load began (see MergeRemoteBoard)
+}
+
+// outboxReplayedMsg reports the result of replaying the offline outbox
+type outboxReplayedMsg struct {
+	result OutboxReplayResult
+}
+
+// boardRefreshMsg asks for the board to be reloaded from the backend in the background
+type boardRefreshMsg struct{}
+
+// cardSavedMsg reports the result of saving the card form
+type cardSavedMsg struct {
+	backend Backend // Backend the card was saved to
+	card    *Card   // The card as saved (with the backend-assigned ID when created)
+	created bool
+	warning string // The card was saved but not completely (e.g. its tags)
+	err     error
+}
+
+// syncPlannedMsg carries a planned GitHub sync of the local board
+type syncPlannedMsg struct {
+	session *SyncSession
+	err     error
+}
+
+// syncAppliedMsg reports the result of applying a GitHub sync
+type syncAppliedMsg struct {
+	result SyncResult
+	err    error
+}
+
+// cardDeletedMsg reports the result of deleting a card through the backend
+type cardDeletedMsg struct {
+	backend Backend // Backend the card was deleted from
+	cardID  string
+	err     error
+}
+
+// cardConvertedMsg reports the result of converting a draft card to an issue
+type cardConvertedMsg struct {
+	backend Backend // Backend the card was converted on
+	cardID  string  // The card's ID before the conversion
+	card    Card    // The converted card, with its new ID, content and URL
+	err     error
+}
+
+// cardMoveSyncedMsg reports the result of a queued backend move
+// The source position and previous ModifiedAt let a failed move be undone
+type cardMoveSyncedMsg struct {
+	backend    Backend // Backend the move was sent to
+	cardID     string
+	fromColumn string
+	fromIndex  int
+	toColumn   string
+	modifiedAt time.Time
+	err        error
 }
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"fmt"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -41,12 +44,69 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case boardLoadedMsg:
+		if msg.backend != m.backend {
+			return m, nil // The user switched projects while this refresh was in flight
+		}
 		if msg.err != nil {
-			// Handle error - for now just keep current board
+			// Keep showing the current (possibly cached) board
+			m.refreshing = false
+			m.statusMessage = fmt.Sprintf("Refresh failed: %v", msg.err)
 			return m, nil
 		}
-		m.board = msg.board
+		if m.draggingCard != nil {
+			// Don't swap the board out from under a drag; try again once it's dropped
+			return m, tea.Tick(time.Second, func(time.Time) tea.Msg { return msg })
+		}
+		m.refreshing = false
+		diff := m.applyRemoteBoard(msg.board, msg.started)
+		m.highlightRemoteChanges(diff)
 		return m, nil
+
+	case boardRefreshMsg:
+		next := refreshTickCmd(m.refreshEvery)
+		if m.refreshEvery <= 0 {
+			next = nil
+		}
+		if m.refreshing || m.lastSynced.IsZero() {
+			return m, next // Already refreshing, or a local board
+		}
+		m.refreshing = true
+		return m, tea.Batch(loadBoardCmd(m.backend), next)
+
+	case syncClockMsg:
+		// Nothing to update; the tick just redraws the "synced Xs ago" indicator
+		return m, syncClockCmd()
+
+	case outboxTickMsg:
+		replayer, ok := m.backend.(OutboxReplayer)
+		if !ok || m.replaying || len(replayer.PendingCards()) == 0 {
+			return m, outboxTickCmd()
+		}
+		m.replaying = true
+		return m, tea.Batch(replayOutboxCmd(replayer), outboxTickCmd())
+
+	case outboxReplayedMsg:
+		m.replaying = false
+		m.applyOutboxReplay(msg.result)
+		return m, nil
+
+	case cardSavedMsg:
+		return m.handleCardSaved(msg)
+
+	case cardDeletedMsg:
+		return m.handleCardDeleted(msg)
+
+	case cardConvertedMsg:
+		return m.handleCardConverted(msg)
+
+	case syncPlannedMsg:
+		return m.handleSyncPlanned(msg)
+
+	case syncAppliedMsg:
+		return m.handleSyncApplied(msg)
+
+	case cardMoveSyncedMsg:
+		return m.handleCardMoveSynced(msg)
 	}
 
 	return m, nil
@@ -6,6 +6,9 @@ import (
 
 // handleKeyMsg handles keyboard input
 func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Status messages last until the next key press
+	m.statusMessage = ""
+
 	// Handle form input first if form is open
 	if m.formMode != FormNone {
 		return m.handleFormKeyMsg(msg)
@@ -16,6 +19,11 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleDeleteConfirmation(msg)
 	}
 
+	// Handle convert-to-issue confirmation
+	if m.convertingCardID != "" {
+		return m.handleConvertConfirmation(msg)
+	}
+
 	// Global shortcuts
 	switch msg.String() {
 	case "q", "ctrl+c":
@@ -60,6 +68,8 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleHelpKeyMsg(msg)
 	case ViewProjectSource:
 		return m.handleProjectSourceKeyMsg(msg)
+	case ViewSyncConflicts:
+		return m.handleSyncConflictsKeyMsg(msg)
 	}
 
 	return m, nil
@@ -82,11 +92,12 @@ func (m Model) handleProjectListKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "enter":
 		// Load the selected project
-		if err := m.loadSelectedProject(); err != nil {
+		cmd, err := m.loadSelectedProject()
+		if err != nil {
 			// TODO: Show error message
 			return m, nil
 		}
-		return m, nil
+		return m, cmd
 	}
 
 	return m, nil
@@ -167,6 +178,15 @@ func (m Model) handleBoardKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case "ctrl+g":
+		// Convert draft card to a repository issue, after confirmation
+		m.askConvertCardToIssue(m.getCurrentCard())
+		return m, nil
+
+	case "S":
+		// Two-way sync with the linked GitHub project
+		return m, m.startSync()
+
 	case "m":
 		// Move card
 		return m, nil
@@ -215,6 +235,18 @@ func (m Model) handleTableKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	// Export the table's cards
+	case "x":
+		m.formMode = FormExport
+		m.selectedExportOpt = 0
+		return m, nil
+
+	// Sort by due date (toggle); a control key so typing D still filters
+	case "ctrl+d":
+		m.tableSortDue = !m.tableSortDue
+		m.buildTable()
+		return m, nil
+
 	// Toggle archive
 	case "a":
 		m.toggleArchive()
@@ -226,6 +258,16 @@ func (m Model) handleTableKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.openEditCardForm()
 		return m, nil
 
+	// Convert draft card to a repository issue, after confirmation; a control
+	// key so typing I still filters
+	case "ctrl+g":
+		m.askConvertCardToIssue(m.getSelectedCardInTable())
+		return m, nil
+
+	// Two-way sync with the linked GitHub project
+	case "S":
+		return m, m.startSync()
+
 	// Delete selected card - show confirmation
 	case "d":
 		card := m.getSelectedCardInTable()
@@ -304,6 +346,45 @@ func (m Model) handleFormKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Template picker shown before the create form
+	if m.formMode == FormPickTemplate {
+		switch msg.String() {
+		case "esc":
+			m.closeCardForm()
+		case "up", "k":
+			if m.selectedTemplate > 0 {
+				m.selectedTemplate--
+			}
+		case "down", "j":
+			if m.selectedTemplate < len(m.templates) { // Blank card + templates
+				m.selectedTemplate++
+			}
+		case "enter":
+			m.selectTemplate()
+		}
+		return m, nil
+	}
+
+	// Export format picker
+	if m.formMode == FormExport {
+		switch msg.String() {
+		case "esc":
+			m.formMode = FormNone
+		case "up", "k":
+			if m.selectedExportOpt > 0 {
+				m.selectedExportOpt--
+			}
+		case "down", "j":
+			if m.selectedExportOpt < len(exportFormats)-1 {
+				m.selectedExportOpt++
+			}
+		case "enter":
+			m.formMode = FormNone
+			m.exportTable(exportFormats[m.selectedExportOpt])
+		}
+		return m, nil
+	}
+
 	// Regular card form handling
 	switch msg.String() {
 	case "esc":
@@ -313,8 +394,7 @@ func (m Model) handleFormKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "ctrl+s", "ctrl+enter":
 		// Save form
-		m.saveCardForm()
-		return m, nil
+		return m, m.saveCardForm()
 
 	case "tab", "shift+tab", "up", "down":
 		// Navigate between form fields
@@ -344,8 +424,7 @@ func (m Model) handleFormKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		// Enter on last field saves the form
 		if m.formFocusIndex == len(m.formInputs)-1 {
-			m.saveCardForm()
-			return m, nil
+			return m, m.saveCardForm()
 		}
 		// Otherwise move to next field
 		m.formFocusIndex++
@@ -431,10 +510,10 @@ func (m Model) handleDeleteConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
 		// Confirm delete
-		m.confirmDelete()
+		cmd := m.confirmDelete()
 		m.confirmingDelete = false
 		m.deletingCardID = ""
-		return m, nil
+		return m, cmd
 
 	case "n", "N", "esc":
 		// Cancel delete
@@ -446,45 +525,26 @@ func (m Model) handleDeleteConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// confirmDelete actually deletes the card after confirmation
-func (m *Model) confirmDelete() {
-	if m.deletingCardID == "" {
-		return
-	}
-
-	// Find and delete the card from the board
-	for i, c := range m.board.Cards {
-		if c.ID == m.deletingCardID {
-			m.board.Cards = append(m.board.Cards[:i], m.board.Cards[i+1:]...)
-			break
-		}
-	}
-
-	// Remove from columns
-	for i := range m.board.Columns {
-		for j, c := range m.board.Columns[i].Cards {
-			if c.ID == m.deletingCardID {
-				m.board.Columns[i].Cards = append(m.board.Columns[i].Cards[:j], m.board.Columns[i].Cards[j+1:]...)
-				break
-			}
-		}
-	}
+// handleConvertConfirmation handles keyboard input when confirming a conversion to an issue
+func (m Model) handleConvertConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		cmd := m.convertCardToIssue(m.board.FindCard(m.convertingCardID))
+		m.convertingCardID = ""
+		return m, cmd
 
-	// Save changes
-	if m.backend != nil {
-		m.backend.SaveBoard(m.board)
+	case "n", "N", "esc":
+		m.convertingCardID = ""
+		return m, nil
 	}
 
-	// Rebuild table if in table view
-	if m.viewMode == ViewTable {
-		m.buildTable()
-	}
+	return m, nil
+}
 
-	// Adjust selection if in board view
-	if m.viewMode == ViewBoard {
-		col := m.getCurrentColumn()
-		if col != nil && m.selectedCard >= len(col.Cards) && m.selectedCard > 0 {
-			m.selectedCard--
-		}
+// confirmDelete actually deletes the card after confirmation
+func (m *Model) confirmDelete() tea.Cmd {
+	if m.deletingCardID == "" {
+		return nil
 	}
+	return m.deleteCardByID(m.deletingCardID)
 }
@@ -155,13 +155,14 @@ func (m Model) handleMouseRelease(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	m.mouseHeldDown = false
 
 	// If we were actually dragging, handle the drop
+	var cmd tea.Cmd
 	if m.draggingCard != nil {
 		// Get drop position
 		toColIndex, insertIndex := m.getDropPosition(msg.X, msg.Y)
 
 		if toColIndex != -1 {
 			// Move card to the target position
-			m.moveCard(m.dragFromColumn, m.dragFromIndex, toColIndex, insertIndex)
+			cmd = m.moveCard(m.dragFromColumn, m.dragFromIndex, toColIndex, insertIndex)
 		}
 
 		// Clear drag state
@@ -175,5 +176,5 @@ func (m Model) handleMouseRelease(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	m.dragFromColumn = -1
 	m.dragFromIndex = -1
 
-	return m, nil
+	return m, cmd
 }
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startSync plans a two-way sync of the local board with its linked GitHub project
+// The plan is built in the background; see handleSyncPlanned
+func (m *Model) startSync() tea.Cmd {
+	path, ok := localBoardPath(m.backend)
+	if !ok {
+		m.statusMessage = "Sync works on local boards linked to a GitHub project"
+		return nil
+	}
+	if m.board.Sync == nil {
+		m.statusMessage = "Board isn't linked to GitHub yet: run tkan sync --github owner/project-number"
+		return nil
+	}
+	if m.syncing {
+		return nil
+	}
+
+	m.syncing = true
+	m.statusMessage = fmt.Sprintf("Syncing with %s…", m.board.Sync.Remote)
+	return func() tea.Msg {
+		session, err := StartSync(path, "", "")
+		return syncPlannedMsg{session: session, err: err}
+	}
+}
+
+// syncBlocksEdits reports whether a sync is running, setting statusMessage if so
+// A sync saves the board it loaded when it started, so edits made meanwhile would be lost
+func (m *Model) syncBlocksEdits() bool {
+	if m.syncing {
+		m.statusMessage = "Sync in progress; edit the board once it finishes"
+	}
+	return m.syncing
+}
+
+// applySyncCmd applies a planned sync in the background
+func applySyncCmd(session *SyncSession) tea.Cmd {
+	return func() tea.Msg {
+		defer session.Close()
+		result, err := session.Apply()
+		return syncAppliedMsg{result: result, err: err}
+	}
+}
+
+// handleSyncPlanned applies a conflict-free plan, or opens the resolution view
+func (m Model) handleSyncPlanned(msg syncPlannedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.syncing = false
+		m.statusMessage = fmt.Sprintf("Sync failed: %v", msg.err)
+		return m, nil
+	}
+	if len(msg.session.Plan.Conflicts) == 0 {
+		return m, applySyncCmd(msg.session)
+	}
+
+	m.syncSession = msg.session
+	m.syncConflict = 0
+	m.syncReturn = m.viewMode
+	m.viewMode = ViewSyncConflicts
+	return m, nil
+}
+
+// handleSyncApplied reloads the synced board and reports the result
+func (m Model) handleSyncApplied(msg syncAppliedMsg) (tea.Model, tea.Cmd) {
+	m.syncing = false
+	if msg.err != nil {
+		m.statusMessage = fmt.Sprintf("Sync failed: %v", msg.err)
+		return m, nil
+	}
+
+	if board, err := m.backend.LoadBoard(); err == nil {
+		var selectedID string
+		if card := m.getCurrentCard(); card != nil {
+			selectedID = card.ID
+		}
+		m.board = board
+		if m.viewMode == ViewTable {
+			m.buildTable()
+		}
+		m.selectCardByID(selectedID)
+	}
+
+	m.statusMessage = "Synced: " + msg.result.String()
+	if len(msg.result.Errors) > 0 {
+		m.statusMessage += fmt.Sprintf(" (%v)", msg.result.Errors[0])
+	}
+	return m, nil
+}
+
+// handleSyncConflictsKeyMsg handles keyboard input in the conflict resolution view
+func (m Model) handleSyncConflictsKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.syncSession == nil {
+		m.viewMode = m.syncReturn
+		return m, nil
+	}
+	conflicts := m.syncSession.Plan.Conflicts
+
+	switch msg.String() {
+	case "up", "k":
+		if m.syncConflict > 0 {
+			m.syncConflict--
+		}
+	case "down", "j":
+		if m.syncConflict < len(conflicts)-1 {
+			m.syncConflict++
+		}
+
+	case "left":
+		conflicts[m.syncConflict].Resolution = ResolveKeepLocal
+		if m.syncConflict < len(conflicts)-1 {
+			m.syncConflict++
+		}
+	case "right":
+		conflicts[m.syncConflict].Resolution = ResolveKeepRemote
+		if m.syncConflict < len(conflicts)-1 {
+			m.syncConflict++
+		}
+	case "L":
+		m.syncSession.Plan.ResolveAll(ResolveKeepLocal)
+	case "R":
+		m.syncSession.Plan.ResolveAll(ResolveKeepRemote)
+
+	case "enter":
+		if n := m.syncSession.Plan.Unresolved(); n > 0 {
+			m.statusMessage = fmt.Sprintf("%d conflict(s) still unresolved", n)
+			return m, nil
+		}
+		session := m.syncSession
+		m.syncSession = nil
+		m.viewMode = m.syncReturn
+		return m, applySyncCmd(session)
+
+	case "esc":
+		// Cancel: nothing has been changed yet
+		m.syncSession.Close()
+		m.syncSession = nil
+		m.syncing = false
+		m.viewMode = m.syncReturn
+		m.statusMessage = "Sync cancelled"
+	}
+
+	return m, nil
+}
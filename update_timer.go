@@ -17,4 +17,55 @@ func tickCmd() tea.Cmd {
 	return tea.Tick(dragDelayDuration, func(t time.Time) tea.Msg {
 		return dragStartMsg{}
 	})
-}
\ No newline at end of file
+}
+// outboxRetryInterval is how often queued offline changes are retried
+const outboxRetryInterval = 30 * time.Second
+
+// outboxTickMsg signals that it's time to retry the offline outbox
+type outboxTickMsg struct{}
+
+// outboxTickCmd creates a command that sends an outboxTickMsg after the retry interval
+func outboxTickCmd() tea.Cmd {
+	return tea.Tick(outboxRetryInterval, func(t time.Time) tea.Msg {
+		return outboxTickMsg{}
+	})
+}
+
+// replayOutboxCmd replays the backend's outbox in the background
+func replayOutboxCmd(replayer OutboxReplayer) tea.Cmd {
+	return func() tea.Msg {
+		return outboxReplayedMsg{result: replayer.ReplayOutbox()}
+	}
+}
+
+// syncClockInterval is how often the "synced Xs ago" indicator is redrawn
+const syncClockInterval = time.Second
+
+// syncClockMsg redraws the sync indicator
+type syncClockMsg struct{}
+
+// syncClockCmd creates a command that sends a syncClockMsg after the clock interval
+func syncClockCmd() tea.Cmd {
+	return tea.Tick(syncClockInterval, func(t time.Time) tea.Msg {
+		return syncClockMsg{}
+	})
+}
+
+// loadBoardCmd reloads the board from the backend in the background
+func loadBoardCmd(backend Backend) tea.Cmd {
+	started := time.Now()
+	return func() tea.Msg {
+		board, err := backend.LoadBoard()
+		return boardLoadedMsg{backend: backend, board: board, err: err, started: started}
+	}
+}
+
+// remoteChangeHighlight is how long cards changed by a refresh stay highlighted
+const remoteChangeHighlight = 5 * time.Second
+
+// refreshTickCmd creates a command that asks for a board refresh after interval
+func refreshTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return boardRefreshMsg{}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -41,6 +42,11 @@ func (m Model) View() string {
 		return m.renderHelpView()
 	case ViewProjectSource:
 		return m.renderProjectSourceView()
+	case ViewSyncConflicts:
+		if m.syncSession == nil {
+			return m.renderBoardView()
+		}
+		return m.renderSyncConflictsView()
 	default:
 		return "Unknown view mode"
 	}
@@ -68,6 +74,9 @@ func (m Model) renderBoardView() string {
 	if m.confirmingDelete {
 		return m.renderDeleteConfirmation(boardView)
 	}
+	if m.convertingCardID != "" {
+		return m.renderConvertConfirmation()
+	}
 
 	// Render form overlay if form is open
 	if m.formMode != FormNone {
@@ -211,6 +220,7 @@ func (m Model) renderColumn(col Column, colIndex int, contentHeight int, colWidt
 		startIndex = 0
 	}
 
+	pending := m.pendingCards()
 	for i := startIndex; i < len(col.Cards); i++ {
 		// Show drop indicator before this card if needed
 		if showDropIndicator && m.dropTargetIndex == i {
@@ -230,14 +240,14 @@ func (m Model) renderColumn(col Column, colIndex int, contentHeight int, colWidt
 			if isDragging {
 				columnContent.WriteString(renderCardGhost(card.Title))
 			} else {
-				columnContent.WriteString(renderCard(card.Title, isSelected))
+				columnContent.WriteString(renderCard(cardLabel(card, pending), isSelected, m.cardDueStatus(card), m.changedRemotely(card)))
 			}
 		} else {
 			// Stacked card - show only top 2 lines
 			if isDragging {
 				columnContent.WriteString(renderCardTopLinesGhost(card.Title))
 			} else {
-				columnContent.WriteString(renderCardTopLines(card.Title, isSelected))
+				columnContent.WriteString(renderCardTopLines(cardLabel(card, pending), isSelected, m.cardDueStatus(card), m.changedRemotely(card)))
 			}
 			columnContent.WriteString("\n")
 		}
@@ -304,7 +314,7 @@ func (m Model) renderDetailPanel() string {
 
 	// Due date
 	if card.DueDate != "" {
-		details = append(details, styleDetailLabel.Render("Due: ")+styleDetailValue.Render(card.DueDate))
+		details = append(details, styleDetailLabel.Render("Due: ")+m.renderDueDate(card))
 	}
 
 	// URL
@@ -325,6 +335,26 @@ func (m Model) renderDetailPanel() string {
 		Render(content)
 }
 
+// renderDueDate renders a card's due date with its relative time, colored by urgency
+func (m Model) renderDueDate(card *Card) string {
+	due, ok := card.Due()
+	if !ok {
+		return styleDetailValue.Render(card.DueDate) + styleSubdued.Render(" (unrecognized date)")
+	}
+
+	now := time.Now()
+	text := fmt.Sprintf("%s (%s)", due.Format("Jan 2, 2006"), formatDueRelative(due, now))
+
+	switch card.DueStatusAt(now, m.dueSoonDays) {
+	case DueOverdue:
+		return styleDueOverdue.Render(text)
+	case DueSoon:
+		return styleDueSoon.Render(text)
+	default:
+		return styleDetailValue.Render(text)
+	}
+}
+
 // renderStatus renders the status bar
 func (m Model) renderStatus() string {
 	var help string
@@ -341,12 +371,39 @@ func (m Model) renderStatus() string {
 	default:
 		help = "q: Quit"
 	}
+	if sync := m.renderSyncStatus(); sync != "" {
+		help += " | " + sync
+	}
+	if m.statusMessage != "" {
+		help = m.statusMessage
+	}
 
 	return styleStatus.
 		Width(m.width).
 		Render(help)
 }
 
+// renderSyncStatus returns the backend's sync state: when the board was last
+// fetched, changes waiting in the offline outbox and the remaining API quota
+// ("" for backends that report none of these)
+func (m Model) renderSyncStatus() string {
+	var parts []string
+	if m.refreshing {
+		parts = append(parts, "syncing…")
+	} else if !m.lastSynced.IsZero() {
+		parts = append(parts, formatSyncAge(m.lastSynced, time.Now()))
+	}
+	if pending := len(m.pendingCards()); pending > 0 {
+		parts = append(parts, fmt.Sprintf("⟳ %d card(s) pending sync", pending))
+	}
+	if reporter, ok := m.backend.(RateLimitReporter); ok {
+		if rate, ok := reporter.RateLimit(); ok {
+			parts = append(parts, rate.String())
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
 // renderProjectListView renders the project selection list
 func (m Model) renderProjectListView() string {
 	var sections []string
@@ -457,6 +514,8 @@ ACTIONS
   n              Create new card
   e              Edit selected card
   d              Delete selected card
+  Ctrl+G         Convert draft card to a repository issue (GitHub)
+  S              Sync with the linked GitHub project (tkan sync)
   m              Move card to different column
   Mouse drag     Drag & drop cards between columns
 
@@ -473,6 +532,10 @@ TABLE VIEW
   e              Edit selected card
   d              Delete selected card
   Ctrl+S         Sort by current column (toggle asc/desc)
+  Ctrl+D         Sort by due date (toggle)
+  x              Export shown cards (CSV, Markdown or JSON)
+  Ctrl+G         Convert draft card to a repository issue (GitHub)
+  S              Sync with the linked GitHub project (tkan sync)
   Type letters   Filter current column
   Backspace      Clear filter
   Mouse wheel    Scroll table
@@ -506,24 +569,32 @@ Press ? or Esc or Enter or Space to close this help screen`
 
 // renderDeleteConfirmation renders the delete confirmation dialog as an overlay
 func (m Model) renderDeleteConfirmation(background string) string {
+	return m.renderConfirmation("Delete Card?", m.deletingCardID, "This action cannot be undone.")
+}
+
+// renderConvertConfirmation renders the convert-to-issue confirmation dialog as an overlay
+func (m Model) renderConvertConfirmation() string {
+	return m.renderConfirmation("Convert to Issue?", m.convertingCardID,
+		"The issue is created in the project's repository, where everyone who can see the repository can read it. This can't be undone.")
+}
+
+// renderConfirmation renders a yes/no dialog about a card as an overlay
+func (m Model) renderConfirmation(title, cardID, warning string) string {
 	// Find the card title to show in confirmation
 	var cardTitle string
-	for _, card := range m.board.Cards {
-		if card.ID == m.deletingCardID {
-			cardTitle = card.Title
-			break
-		}
+	if card := m.board.FindCard(cardID); card != nil {
+		cardTitle = card.Title
 	}
 
 	// Build confirmation message
 	var confirmLines []string
-	confirmLines = append(confirmLines, styleDetailTitle.Render("Delete Card?"))
+	confirmLines = append(confirmLines, styleDetailTitle.Render(title))
 	confirmLines = append(confirmLines, "")
 	if cardTitle != "" {
 		confirmLines = append(confirmLines, styleDetailLabel.Render("Card: ")+cardTitle)
 		confirmLines = append(confirmLines, "")
 	}
-	confirmLines = append(confirmLines, styleSubdued.Render("This action cannot be undone."))
+	confirmLines = append(confirmLines, styleSubdued.Render(warning))
 	confirmLines = append(confirmLines, "")
 
 	// Colorized prompt with green Y and red N
@@ -562,6 +633,16 @@ func (m Model) renderFormOverlay(background string) string {
 		return m.renderGitHubOwnerInput()
 	}
 
+	// Template picker shown before the create form
+	if m.formMode == FormPickTemplate {
+		return m.renderTemplatePicker()
+	}
+
+	// Export format picker (table view)
+	if m.formMode == FormExport {
+		return m.renderExportPicker()
+	}
+
 	// Determine form title
 	formTitle := "Create New Card"
 	if m.formMode == FormEditCard {
@@ -586,6 +667,23 @@ func (m Model) renderFormOverlay(background string) string {
 	}
 	formLines = append(formLines, "")
 
+	formLines = append(formLines, styleDetailLabel.Render("Due Date:"))
+	if len(m.formInputs) > 2 {
+		formLines = append(formLines, m.formInputs[2].View())
+	}
+	formLines = append(formLines, "")
+
+	if m.formSaving {
+		formLines = append(formLines, styleSubdued.Render("Saving…"))
+		formLines = append(formLines, "")
+	}
+
+	// Validation error (e.g. unparseable due date)
+	if m.formError != "" {
+		formLines = append(formLines, styleDueOverdue.Render(m.formError))
+		formLines = append(formLines, "")
+	}
+
 	// Instructions
 	formLines = append(formLines, styleSubdued.Render("Tab/↑/↓: Navigate fields"))
 	formLines = append(formLines, styleSubdued.Render("Enter: Next field / Save (on last field)"))
@@ -672,6 +770,62 @@ func (m Model) renderProjectSourceView() string {
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
+// renderTemplatePicker renders the template selection list for new cards
+func (m Model) renderTemplatePicker() string {
+	options := []string{"Blank card"}
+	for _, tmpl := range m.templates {
+		options = append(options, tmpl.Name)
+	}
+	return m.renderPicker("New Card From Template", options, m.selectedTemplate)
+}
+
+// renderExportPicker renders the export format selection list
+func (m Model) renderExportPicker() string {
+	options := []string{
+		"CSV (table columns)",
+		"Markdown checklist",
+		"JSON",
+	}
+	return m.renderPicker(fmt.Sprintf("Export %d Cards", len(m.tableCardIndex)), options, m.selectedExportOpt)
+}
+
+// renderPicker renders a centered modal list with one selected option
+func (m Model) renderPicker(title string, options []string, selected int) string {
+	var lines []string
+	lines = append(lines, styleDetailTitle.Render(title))
+	lines = append(lines, "")
+
+	for i, option := range options {
+		prefix := "   "
+		style := styleDetailValue
+		if i == selected {
+			prefix = " ▶ "
+			style = lipgloss.NewStyle().
+				Foreground(colorSelected).
+				Bold(true)
+		}
+		lines = append(lines, style.Render(prefix+option))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, styleSubdued.Render("↑/↓: Navigate | Enter: Select | Esc: Cancel"))
+
+	pickerBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorPrimary).
+		Padding(1, 2).
+		Width(70).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		pickerBox,
+	)
+}
+
 // renderGitHubOwnerInput renders the GitHub owner input form
 func (m Model) renderGitHubOwnerInput() string {
 	var formLines []string
@@ -744,7 +898,13 @@ func (m Model) renderTableView() string {
 	if m.showArchive {
 		archiveStatus = "visible"
 	}
-	help := fmt.Sprintf("↑/↓: Navigate | e: Edit | d: Delete | Ctrl+S: Sort | a: Archive (%s) | v: Board View | q: Quit", archiveStatus)
+	help := fmt.Sprintf("↑/↓: Navigate | e: Edit | d: Delete | Ctrl+S: Sort | Ctrl+D: Sort by due | x: Export | a: Archive (%s) | v: Board View | q: Quit", archiveStatus)
+	if sync := m.renderSyncStatus(); sync != "" {
+		help += " | " + sync
+	}
+	if m.statusMessage != "" {
+		help = m.statusMessage
+	}
 	status := styleStatus.Width(m.width).Render(help)
 	sections = append(sections, status)
 
@@ -754,6 +914,9 @@ func (m Model) renderTableView() string {
 	if m.confirmingDelete {
 		return m.renderDeleteConfirmation(tableView)
 	}
+	if m.convertingCardID != "" {
+		return m.renderConvertConfirmation()
+	}
 
 	// Render form overlay if form is open
 	if m.formMode != FormNone {
@@ -800,7 +963,7 @@ func (m Model) renderTableInfoBox() string {
 		infoLine += styleDetailLabel.Render("Assignee: ") + card.Assignee + "  "
 	}
 	if card.DueDate != "" {
-		infoLine += styleDetailLabel.Render("Due: ") + card.DueDate
+		infoLine += styleDetailLabel.Render("Due: ") + m.renderDueDate(card)
 	}
 	if infoLine != "" {
 		lines = append(lines, infoLine)
@@ -822,3 +985,86 @@ func (m Model) renderTableInfoBox() string {
 		BorderForeground(colorSubdued).
 		Render(content)
 }
+
+// renderSyncConflictsView renders the local and remote versions of each sync conflict side by side
+func (m Model) renderSyncConflictsView() string {
+	var sections []string
+
+	conflicts := m.syncSession.Plan.Conflicts
+	title := styleTitle.Width(m.width).Render(fmt.Sprintf("📋 tkan - Sync Conflicts with %s", m.board.Sync.Remote))
+	sections = append(sections, title)
+
+	var lines []string
+	lines = append(lines, "")
+	for i, conflict := range conflicts {
+		prefix := "   "
+		style := styleDetailValue
+		if i == m.syncConflict {
+			prefix = " ▶ "
+			style = lipgloss.NewStyle().Foreground(colorSelected).Bold(true)
+		}
+		choice := "?"
+		switch conflict.Resolution {
+		case ResolveKeepLocal:
+			choice = "local"
+		case ResolveKeepRemote:
+			choice = "remote"
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%s[%-6s] %s", prefix, choice, conflict.Title())))
+	}
+	lines = append(lines, "")
+
+	// Both versions of the selected conflict
+	if m.syncConflict < len(conflicts) {
+		conflict := conflicts[m.syncConflict]
+		half := (m.width - 12) / 2
+		if half < 20 {
+			half = 20
+		}
+		local := renderSyncVersion("Local (←)", conflict.Local, half)
+		remote := renderSyncVersion("Remote (→)", conflict.Remote, half)
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, local, "  ", remote))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, styleSubdued.Render("↑/↓: Navigate | ←: Keep local | →: Keep remote | L/R: All local/remote | Enter: Apply | Esc: Cancel"))
+
+	contentStyle := lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height - 4).
+		Padding(1, 4)
+	sections = append(sections, contentStyle.Render(strings.Join(lines, "\n")))
+
+	statusText := fmt.Sprintf("%d conflict(s), %d unresolved", len(conflicts), m.syncSession.Plan.Unresolved())
+	if m.statusMessage != "" {
+		statusText = m.statusMessage
+	}
+	sections = append(sections, styleStatus.Width(m.width).Render(statusText))
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// renderSyncVersion renders one side of a sync conflict (card is nil when that side deleted it)
+func renderSyncVersion(heading string, card *Card, width int) string {
+	lines := []string{styleDetailTitle.Render(heading), ""}
+	if card == nil {
+		lines = append(lines, styleSubdued.Render("(deleted)"))
+	} else {
+		field := func(label, value string) {
+			if value == "" {
+				value = "-"
+			}
+			lines = append(lines, styleDetailLabel.Render(label+": ")+styleDetailValue.Render(value))
+		}
+		field("Title", card.Title)
+		field("Column", card.Column)
+		field("Assignee", card.Assignee)
+		field("Due", card.DueDate)
+		field("Tags", strings.Join(card.Tags, ", "))
+		if !card.ModifiedAt.IsZero() {
+			field("Modified", card.ModifiedAt.Local().Format("2006-01-02 15:04"))
+		}
+		lines = append(lines, "", styleDetailValue.Render(card.Description))
+	}
+	return stylePanelBorder.Width(width).Render(strings.Join(lines, "\n"))
+}